@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/speier/tokenscout/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+var deployersLimit int
+
+var deployersCmd = &cobra.Command{
+	Use:   "deployers",
+	Short: "Deployer reputation and blacklist",
+	Long: `Wallets that created the tokens we traded, with our results on their tokens.
+Deployers whose tokens rugged us are blacklisted automatically.`,
+}
+
+var deployersListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List blacklisted deployers and deployers we have traded",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		repo, err := repository.NewSQLite(dbPath)
+		if err != nil {
+			return fmt.Errorf("failed to initialize repository: %w", err)
+		}
+		defer repo.Close()
+
+		stats, err := repo.GetDeployerStats(context.Background(), deployersLimit)
+		if err != nil {
+			return fmt.Errorf("failed to get deployer stats: %w", err)
+		}
+
+		if len(stats) == 0 {
+			fmt.Println("No deployers tracked yet. Deployers are recorded as the deployer rule checks new tokens.")
+			return nil
+		}
+
+		fmt.Printf("%-44s %6s %7s %7s %12s  %s\n", "Deployer", "Mints", "Closed", "Losses", "PnL USD", "Blacklisted")
+		fmt.Println("------------------------------------------------------------------------------------------------------")
+		for _, s := range stats {
+			blacklisted := "-"
+			if s.Blacklisted {
+				blacklisted = s.BlacklistReason
+				if s.BlacklistMint != "" {
+					blacklisted += " (" + shortAddress(s.BlacklistMint) + ")"
+				}
+			}
+			fmt.Printf("%-44s %6d %7d %7d $%11.2f  %s\n", s.Wallet, s.Mints, s.Closed, s.Losses, s.PnLUSD, blacklisted)
+		}
+		return nil
+	},
+}
+
+var deployersBlockCmd = &cobra.Command{
+	Use:   "block <wallet>",
+	Short: "Blacklist a deployer wallet by hand",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		repo, err := repository.NewSQLite(dbPath)
+		if err != nil {
+			return fmt.Errorf("failed to initialize repository: %w", err)
+		}
+		defer repo.Close()
+
+		if err := repo.BlacklistDeployer(context.Background(), args[0], "", "manual"); err != nil {
+			return fmt.Errorf("failed to blacklist deployer: %w", err)
+		}
+		fmt.Printf("Blacklisted deployer %s\n", args[0])
+		return nil
+	},
+}
+
+var deployersUnblockCmd = &cobra.Command{
+	Use:   "unblock <wallet>",
+	Short: "Remove a deployer wallet from the blacklist",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		repo, err := repository.NewSQLite(dbPath)
+		if err != nil {
+			return fmt.Errorf("failed to initialize repository: %w", err)
+		}
+		defer repo.Close()
+
+		if err := repo.RemoveDeployerFromBlacklist(context.Background(), args[0]); err != nil {
+			return fmt.Errorf("failed to unblock deployer: %w", err)
+		}
+		fmt.Printf("Removed deployer %s from the blacklist\n", args[0])
+		return nil
+	},
+}
+
+// shortAddress abbreviates an address for table output
+func shortAddress(address string) string {
+	if len(address) <= 8 {
+		return address
+	}
+	return address[:4] + ".." + address[len(address)-4:]
+}
+
+func init() {
+	deployersListCmd.Flags().IntVarP(&deployersLimit, "limit", "l", 50, "number of deployers to show")
+	deployersCmd.AddCommand(deployersListCmd)
+	deployersCmd.AddCommand(deployersBlockCmd)
+	deployersCmd.AddCommand(deployersUnblockCmd)
+	rootCmd.AddCommand(deployersCmd)
+}
@@ -7,7 +7,9 @@ import (
 
 	"github.com/speier/tokenscout/internal/config"
 	"github.com/speier/tokenscout/internal/engine"
+	"github.com/speier/tokenscout/internal/logger"
 	"github.com/speier/tokenscout/internal/repository"
+	"github.com/speier/tokenscout/internal/solana"
 	"github.com/spf13/cobra"
 )
 
@@ -29,7 +31,7 @@ var positionsCmd = &cobra.Command{
 		eng := engine.New(repo, cfg)
 		ctx := context.Background()
 
-		positions, err := eng.GetPositions(ctx)
+		positions, err := eng.GetPositionMarks(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to get positions: %w", err)
 		}
@@ -49,6 +51,74 @@ var positionsCmd = &cobra.Command{
 	},
 }
 
+var reconcileYes bool
+
+var positionsReconcileCmd = &cobra.Command{
+	Use:   "reconcile",
+	Short: "Sync open positions with on-chain wallet balances",
+	Long: `Compare open positions with the wallet's token balances and show the differences.
+Positions whose quantity drifted are adjusted, positions whose tokens are gone are
+cleared, and tokens held without a position are flagged. Changes are only applied
+after confirmation. Live mode runs this automatically on start.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger.Init(logLevel, true)
+
+		cfg, err := config.Load(cfgFile)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		repo, err := repository.NewSQLite(dbPath)
+		if err != nil {
+			return fmt.Errorf("failed to initialize repository: %w", err)
+		}
+		defer repo.Close()
+
+		eng := engine.New(repo, cfg)
+		ctx := context.Background()
+
+		report, err := eng.Reconcile(ctx, false)
+		if err != nil {
+			return fmt.Errorf("failed to reconcile positions: %w", err)
+		}
+
+		if report.InSync() {
+			fmt.Printf("All %d position(s) match the wallet\n", report.Positions)
+			return nil
+		}
+
+		fmt.Printf("%-8s %-44s %20s %20s\n", "Action", "Mint", "Recorded", "Wallet")
+		for _, diff := range report.Diffs {
+			fmt.Printf("%-8s %-44s %20s %20s\n",
+				diff.Action,
+				diff.Mint,
+				solana.FormatUIAmount(diff.RecordedRaw, diff.Decimals),
+				solana.FormatUIAmount(diff.WalletRaw, diff.Decimals),
+			)
+		}
+
+		if !reconcileYes {
+			fmt.Print("\nApply these changes? (yes/no): ")
+			var confirm string
+			fmt.Scanln(&confirm)
+
+			if confirm != "yes" {
+				fmt.Println("Cancelled")
+				return nil
+			}
+		}
+
+		if _, err := eng.Reconcile(ctx, true); err != nil {
+			return fmt.Errorf("failed to apply reconciliation: %w", err)
+		}
+
+		fmt.Println("Positions reconciled")
+		return nil
+	},
+}
+
 func init() {
+	positionsReconcileCmd.Flags().BoolVarP(&reconcileYes, "yes", "y", false, "apply without confirmation")
+	positionsCmd.AddCommand(positionsReconcileCmd)
 	rootCmd.AddCommand(positionsCmd)
 }
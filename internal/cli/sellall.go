@@ -41,7 +41,8 @@ var sellAllCmd = &cobra.Command{
 		jupiterClient := solana.NewJupiterClient(cfg.Solana.JupiterAPIURL)
 
 		// Create executor
-		executor := engine.NewExecutor(cfg, repo, solanaClient, jupiterClient)
+		executor := engine.NewExecutor(cfg, repo, solanaClient, jupiterClient,
+			solana.NewPriceService(jupiterClient, solana.DefaultPriceTTL), engine.NewRiskGovernor(cfg, repo))
 
 		// Get positions
 		ctx := context.Background()
@@ -57,7 +58,7 @@ var sellAllCmd = &cobra.Command{
 
 		fmt.Printf("Found %d open position(s)\n", len(positions))
 		for _, pos := range positions {
-			fmt.Printf("  - %s (qty: %s)\n", pos.Mint, pos.Quantity)
+			fmt.Printf("  - %s, qty: %s\n", tokenLabel(pos.Symbol, pos.Mint), pos.Quantity)
 		}
 
 		// Confirm
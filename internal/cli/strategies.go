@@ -80,13 +80,13 @@ var strategiesCompareCmd = &cobra.Command{
 		fmt.Println()
 
 		// Print table header
-		fmt.Printf("%-18s %8s %8s %8s %8s %12s %12s %10s\n",
-			"Strategy", "Trades", "Buy", "Sell", "Open", "Avg Entry", "Volume USD", "Success %")
-		fmt.Println("-------------------------------------------------------------------------------------------")
+		fmt.Printf("%-18s %8s %8s %8s %8s %12s %12s %10s %8s %8s %12s %8s %8s\n",
+			"Strategy", "Trades", "Buy", "Sell", "Open", "Avg Entry", "Volume USD", "Success %", "Closed", "Win %", "PnL USD", "MFE %", "MAE %")
+		fmt.Println("------------------------------------------------------------------------------------------------------------------------------------------")
 
 		// Print each strategy
 		for _, s := range stats {
-			fmt.Printf("%-18s %8d %8d %8d %8d $%11.6f $%11.2f %9.1f%%\n",
+			fmt.Printf("%-18s %8d %8d %8d %8d $%11.6f $%11.2f %9.1f%% %8d %7.1f%% $%11.2f %7.1f%% %7.1f%%\n",
 				s.Strategy,
 				s.TotalTrades,
 				s.BuyTrades,
@@ -95,6 +95,11 @@ var strategiesCompareCmd = &cobra.Command{
 				s.AvgEntryPrice,
 				s.TotalVolume,
 				s.SuccessRate,
+				s.ClosedTrades,
+				s.WinRate,
+				s.RealizedPnLUSD,
+				s.AvgMFEPct,
+				s.AvgMAEPct,
 			)
 		}
 
@@ -103,6 +108,10 @@ var strategiesCompareCmd = &cobra.Command{
 		fmt.Println("  • Success % = (Executed Trades / Total Trades) × 100")
 		fmt.Println("  • Volume USD = Total value of buy trades executed")
 		fmt.Println("  • Avg Entry = Average price paid when buying (USD per token)")
+		fmt.Println("  • Closed = Fully closed positions recorded in the realized PnL ledger")
+		fmt.Println("  • Win % = (Closed positions with PnL > 0 / Closed positions) × 100")
+		fmt.Println("  • PnL USD = Realized PnL after fees across closed positions")
+		fmt.Println("  • MFE % / MAE % = Average best / worst price reached vs entry while positions were open")
 		fmt.Println("═══════════════════════════════════════════════════════════════════════════════════════")
 		fmt.Println()
 
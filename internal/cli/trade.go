@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/speier/tokenscout/internal/config"
+	"github.com/speier/tokenscout/internal/engine"
+	"github.com/speier/tokenscout/internal/logger"
+	"github.com/speier/tokenscout/internal/models"
+	"github.com/speier/tokenscout/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+var (
+	buySOL      float64
+	buyForce    bool
+	sellPct     float64
+	tradeDryRun bool
+)
+
+var buyCmd = &cobra.Command{
+	Use:   "buy <mint>",
+	Short: "Manually buy a token",
+	Long: `Buy a token by hand. The token must pass the same rules as automatic buys
+(use --force to skip them), and position limits and circuit breakers still apply.
+Without --sol the configured position sizing decides the amount.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mint := args[0]
+
+		eng, repo, err := openTradingEngine()
+		if err != nil {
+			return err
+		}
+		defer repo.Close()
+
+		ctx := context.Background()
+
+		// The executor skips a buy into a position it already holds, which
+		// would otherwise be reported below as a fresh fill
+		if position, err := repo.GetPosition(ctx, mint); err == nil {
+			return fmt.Errorf("already holding %s %s, nothing bought", position.Quantity, tokenLabel(position.Symbol, mint))
+		}
+
+		if err := eng.Buy(ctx, mint, buySOL, !buyForce); err != nil {
+			return fmt.Errorf("buy failed: %w", err)
+		}
+
+		// The executor skips buys it isn't allowed to make rather than failing
+		position, err := repo.GetPosition(ctx, mint)
+		if err != nil {
+			fmt.Println("No position opened (see log for the reason)")
+			return nil
+		}
+
+		fmt.Printf("Bought %s %s at $%.10f\n", position.Quantity, tokenLabel(position.Symbol, mint), position.AvgPriceUSD)
+		return nil
+	},
+}
+
+var sellCmd = &cobra.Command{
+	Use:   "sell <mint>",
+	Short: "Manually sell all or part of a position",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mint := args[0]
+
+		eng, repo, err := openTradingEngine()
+		if err != nil {
+			return err
+		}
+		defer repo.Close()
+
+		ctx := context.Background()
+		label := positionLabel(ctx, repo, mint)
+		if err := eng.SellPosition(ctx, mint, sellPct); err != nil {
+			return fmt.Errorf("sell failed: %w", err)
+		}
+
+		fmt.Printf("Sold %.0f%% of %s\n", sellPct, label)
+		return nil
+	},
+}
+
+var positionsCloseCmd = &cobra.Command{
+	Use:   "close <mint>",
+	Short: "Sell a whole position",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mint := args[0]
+
+		eng, repo, err := openTradingEngine()
+		if err != nil {
+			return err
+		}
+		defer repo.Close()
+
+		ctx := context.Background()
+		label := positionLabel(ctx, repo, mint)
+		if err := eng.ClosePosition(ctx, mint); err != nil {
+			return fmt.Errorf("failed to close position: %w", err)
+		}
+
+		fmt.Printf("Closed position %s\n", label)
+		return nil
+	},
+}
+
+// tokenLabel shows a token as "SYMBOL (mint)", or just the mint when the
+// symbol is unknown
+func tokenLabel(symbol, mint string) string {
+	if symbol == "" {
+		return mint
+	}
+	return fmt.Sprintf("%s (%s)", symbol, mint)
+}
+
+// positionLabel is tokenLabel for an open position, read before it is sold
+func positionLabel(ctx context.Context, repo repository.Repository, mint string) string {
+	if position, err := repo.GetPosition(ctx, mint); err == nil {
+		return tokenLabel(position.Symbol, mint)
+	}
+	return mint
+}
+
+// openTradingEngine loads config and the database for a one-off manual trade.
+// The caller closes the repository.
+func openTradingEngine() (engine.Engine, repository.Repository, error) {
+	logger.Init(logLevel, true)
+
+	cfg, err := config.Load(cfgFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	if tradeDryRun {
+		cfg.Engine.Mode = models.ModeDryRun
+	}
+	if cfg.Strategy == "" {
+		cfg.Strategy = "custom"
+	}
+
+	repo, err := repository.NewSQLite(dbPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize repository: %w", err)
+	}
+
+	return engine.New(repo, cfg), repo, nil
+}
+
+func init() {
+	buyCmd.Flags().Float64Var(&buySOL, "sol", 0, "SOL to spend (default: configured position sizing)")
+	buyCmd.Flags().BoolVar(&buyForce, "force", false, "skip the token rules")
+	sellCmd.Flags().Float64Var(&sellPct, "pct", 100, "percentage of the position to sell")
+
+	for _, cmd := range []*cobra.Command{buyCmd, sellCmd, positionsCloseCmd} {
+		cmd.Flags().BoolVar(&tradeDryRun, "dry-run", false, "simulate the trade with real quotes")
+	}
+
+	positionsCmd.AddCommand(positionsCloseCmd)
+	rootCmd.AddCommand(buyCmd)
+	rootCmd.AddCommand(sellCmd)
+}
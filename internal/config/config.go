@@ -100,6 +100,18 @@ func LoadWithOverrides(configPath, strategyConfigPath string) (*models.Config, e
 		if v.IsSet("trading.priority_fee_microlamports") {
 			cfg.Trading.PriorityFeeMicroLamports = v.GetInt64("trading.priority_fee_microlamports")
 		}
+		if v.IsSet("trading.sizing_mode") {
+			cfg.Trading.SizingMode = models.SizingMode(v.GetString("trading.sizing_mode"))
+		}
+		if v.IsSet("trading.wallet_pct") {
+			cfg.Trading.WalletPct = v.GetFloat64("trading.wallet_pct")
+		}
+		if v.IsSet("trading.max_price_impact_pct") {
+			cfg.Trading.MaxPriceImpactPct = v.GetFloat64("trading.max_price_impact_pct")
+		}
+		if v.IsSet("trading.min_spend_per_trade") {
+			cfg.Trading.MinSpendPerTrade = v.GetFloat64("trading.min_spend_per_trade")
+		}
 	}
 
 	if v.IsSet("rules") {
@@ -115,6 +127,59 @@ func LoadWithOverrides(configPath, strategyConfigPath string) (*models.Config, e
 		if v.IsSet("rules.dev_wallet_max_pct") {
 			cfg.Rules.DevWalletMaxPct = v.GetFloat64("rules.dev_wallet_max_pct")
 		}
+		if v.IsSet("rules.checks") {
+			cfg.Rules.Checks = v.GetStringSlice("rules.checks")
+		}
+		if v.IsSet("rules.disabled") {
+			cfg.Rules.Disabled = v.GetStringSlice("rules.disabled")
+		}
+		if v.IsSet("rules.full_report") {
+			cfg.Rules.FullReport = v.GetBool("rules.full_report")
+		}
+		if v.IsSet("rules.expressions") {
+			if err := v.UnmarshalKey("rules.expressions", &cfg.Rules.Expressions); err != nil {
+				return nil, fmt.Errorf("invalid rules.expressions: %w", err)
+			}
+		}
+		if v.IsSet("rules.min_lp_burned_pct") {
+			cfg.Rules.MinLPBurnedPct = v.GetFloat64("rules.min_lp_burned_pct")
+		}
+		if v.IsSet("rules.max_round_trip_loss_pct") {
+			cfg.Rules.MaxRoundTripLossPct = v.GetFloat64("rules.max_round_trip_loss_pct")
+		}
+		if v.IsSet("rules.block_mutable_metadata") {
+			cfg.Rules.BlockMutableMetadata = v.GetBool("rules.block_mutable_metadata")
+		}
+		if v.IsSet("rules.block_update_authority") {
+			cfg.Rules.BlockUpdateAuthority = v.GetBool("rules.block_update_authority")
+		}
+		if v.IsSet("rules.name_blocklist") {
+			cfg.Rules.NameBlocklist = v.GetStringSlice("rules.name_blocklist")
+		}
+		if v.IsSet("rules.holder_cluster_window_slots") {
+			cfg.Rules.HolderClusterWindowSlots = v.GetInt("rules.holder_cluster_window_slots")
+		}
+		if v.IsSet("rules.block_blacklisted_deployers") {
+			cfg.Rules.BlockBlacklistedDeployers = v.GetBool("rules.block_blacklisted_deployers")
+		}
+		if v.IsSet("rules.deployer_fast_stop_sec") {
+			cfg.Rules.DeployerFastStopSec = v.GetInt("rules.deployer_fast_stop_sec")
+		}
+		if v.IsSet("rules.max_transfer_fee_pct") {
+			cfg.Rules.MaxTransferFeePct = v.GetFloat64("rules.max_transfer_fee_pct")
+		}
+		if v.IsSet("rules.block_transfer_hook") {
+			cfg.Rules.BlockTransferHook = v.GetBool("rules.block_transfer_hook")
+		}
+		if v.IsSet("rules.block_permanent_delegate") {
+			cfg.Rules.BlockPermanentDelegate = v.GetBool("rules.block_permanent_delegate")
+		}
+		if v.IsSet("rules.block_default_frozen") {
+			cfg.Rules.BlockDefaultFrozen = v.GetBool("rules.block_default_frozen")
+		}
+		if v.IsSet("rules.block_non_transferable") {
+			cfg.Rules.BlockNonTransferable = v.GetBool("rules.block_non_transferable")
+		}
 	}
 
 	if v.IsSet("risk") {
@@ -127,6 +192,41 @@ func LoadWithOverrides(configPath, strategyConfigPath string) (*models.Config, e
 		if v.IsSet("risk.max_trade_duration_sec") {
 			cfg.Risk.MaxTradeDurationSec = v.GetInt("risk.max_trade_duration_sec")
 		}
+		if v.IsSet("risk.trailing_stop_pct") {
+			cfg.Risk.TrailingStopPct = v.GetFloat64("risk.trailing_stop_pct")
+		}
+		if v.IsSet("risk.breakeven_trigger_pct") {
+			cfg.Risk.BreakevenTriggerPct = v.GetFloat64("risk.breakeven_trigger_pct")
+		}
+		if v.IsSet("risk.take_profit_ladder") {
+			if err := v.UnmarshalKey("risk.take_profit_ladder", &cfg.Risk.TakeProfitLadder); err != nil {
+				return nil, fmt.Errorf("invalid take_profit_ladder: %w", err)
+			}
+		}
+		if v.IsSet("risk.max_daily_loss_usd") {
+			cfg.Risk.MaxDailyLossUSD = v.GetFloat64("risk.max_daily_loss_usd")
+		}
+		if v.IsSet("risk.max_exposure_sol") {
+			cfg.Risk.MaxExposureSOL = v.GetFloat64("risk.max_exposure_sol")
+		}
+		if v.IsSet("risk.max_consecutive_losses") {
+			cfg.Risk.MaxConsecutiveLosses = v.GetInt("risk.max_consecutive_losses")
+		}
+		if v.IsSet("risk.loss_cooldown_sec") {
+			cfg.Risk.LossCooldownSec = v.GetInt("risk.loss_cooldown_sec")
+		}
+		if v.IsSet("risk.rug_check_interval_sec") {
+			cfg.Risk.RugCheckIntervalSec = v.GetInt("risk.rug_check_interval_sec")
+		}
+		if v.IsSet("risk.rug_liquidity_drop_pct") {
+			cfg.Risk.RugLiquidityDropPct = v.GetFloat64("risk.rug_liquidity_drop_pct")
+		}
+		if v.IsSet("risk.rug_holder_dump_pct") {
+			cfg.Risk.RugHolderDumpPct = v.GetFloat64("risk.rug_holder_dump_pct")
+		}
+		if v.IsSet("risk.rug_exit_on_authority_change") {
+			cfg.Risk.RugExitOnAuthorityChange = v.GetBool("risk.rug_exit_on_authority_change")
+		}
 	}
 
 	return cfg, nil
@@ -159,6 +259,7 @@ func setDefaults(v *viper.Viper) {
 		"9W959DqEETiGZocYWCQPaJ6sBmUzgfxXfqGeTEdp3aQP", // Orca Whirlpool (2nd largest DEX)
 	})
 	v.SetDefault("listener.coalesce_window_ms", 200)
+	v.SetDefault("listener.price_stream", true) // Live exit prices from pool reserves, polling as fallback
 
 	v.SetDefault("trading.base_mint", "SOL")
 	v.SetDefault("trading.quote_mint", "USDC")
@@ -166,19 +267,53 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("trading.max_open_positions", 5)             // More concurrent trades
 	v.SetDefault("trading.slippage_bps", 400)                 // Higher slippage for speed
 	v.SetDefault("trading.priority_fee_microlamports", 20000) // Higher priority for faster confirms
+	v.SetDefault("trading.sizing_mode", "fixed")
+	v.SetDefault("trading.wallet_pct", 2)           // Risk 2% of bankroll in wallet_pct mode
+	v.SetDefault("trading.max_price_impact_pct", 3) // Keep impact under 3% in price_impact mode
+	v.SetDefault("trading.min_spend_per_trade", 0.01)
 
 	// Rules tuned for snipe & flip strategy: catch early, exit fast
-	v.SetDefault("rules.min_liquidity_usd", 3000)      // Need enough liquidity to exit
-	v.SetDefault("rules.max_mint_age_sec", 300)        // Only tokens < 5 minutes old
-	v.SetDefault("rules.min_holders", 3)               // Very early entry
-	v.SetDefault("rules.dev_wallet_max_pct", 40)       // Safer distribution
-	v.SetDefault("rules.block_freeze_authority", true) // CRITICAL: reject if token can be frozen
-	v.SetDefault("rules.allow_mint_authority", false)  // CRITICAL: reject if supply can be minted
+	v.SetDefault("rules.checks", []string{}) // Every registered rule in default order
+	v.SetDefault("rules.disabled", []string{})
+	v.SetDefault("rules.full_report", false) // Stop at the first failure (fewer RPC calls)
+	v.SetDefault("rules.expressions", []models.RuleExpression{})
+
+	v.SetDefault("rules.min_liquidity_usd", 3000)       // Need enough liquidity to exit
+	v.SetDefault("rules.max_mint_age_sec", 300)         // Only tokens < 5 minutes old
+	v.SetDefault("rules.min_holders", 3)                // Very early entry
+	v.SetDefault("rules.dev_wallet_max_pct", 40)        // Safer distribution
+	v.SetDefault("rules.min_lp_burned_pct", 0)          // Off: needs a Raydium pool and extra RPC calls
+	v.SetDefault("rules.max_round_trip_loss_pct", 10)   // Fees and impact cost a few %, taxes far more
+	v.SetDefault("rules.block_freeze_authority", true)  // CRITICAL: reject if token can be frozen
+	v.SetDefault("rules.allow_mint_authority", false)   // CRITICAL: reject if supply can be minted
+	v.SetDefault("rules.block_mutable_metadata", false) // Many legit launches keep metadata mutable
+	v.SetDefault("rules.block_update_authority", false)
+	v.SetDefault("rules.name_blocklist", []string{})
+	v.SetDefault("rules.holder_cluster_window_slots", 0) // Off: traces the top 20 holders, ~40 RPC calls
+
+	v.SetDefault("rules.block_blacklisted_deployers", true) // Serial ruggers are the biggest source of losses
+	v.SetDefault("rules.deployer_fast_stop_sec", 60)        // Stopped out within a minute: treat as a rug
+
+	v.SetDefault("rules.max_transfer_fee_pct", 0) // Token-2022: any transfer fee eats into the flip
+	v.SetDefault("rules.block_transfer_hook", true)
+	v.SetDefault("rules.block_permanent_delegate", true) // Delegate can drain any holder
+	v.SetDefault("rules.block_default_frozen", true)
+	v.SetDefault("rules.block_non_transferable", true) // Can't be sold
 
 	// Risk settings for snipe & flip: quick exits
 	v.SetDefault("risk.stop_loss_pct", 8)            // Quick exit on loss
 	v.SetDefault("risk.take_profit_pct", 18)         // Take profits fast (don't be greedy)
 	v.SetDefault("risk.max_trade_duration_sec", 240) // 4 min max hold (exit before rugs)
+	v.SetDefault("risk.trailing_stop_pct", 0)        // Disabled by default
+	v.SetDefault("risk.breakeven_trigger_pct", 0)    // Disabled by default
+	v.SetDefault("risk.max_daily_loss_usd", 0)       // Circuit breakers disabled by default
+	v.SetDefault("risk.max_exposure_sol", 0)
+	v.SetDefault("risk.max_consecutive_losses", 0)
+	v.SetDefault("risk.loss_cooldown_sec", 1800) // 30 min pause once a loss streak trips
+	v.SetDefault("risk.rug_check_interval_sec", 15)
+	v.SetDefault("risk.rug_liquidity_drop_pct", 30) // Pool lost 30% of its depth
+	v.SetDefault("risk.rug_holder_dump_pct", 50)    // Top holder sold half their bag
+	v.SetDefault("risk.rug_exit_on_authority_change", true)
 }
 
 func CreateDefault(configPath string) error {
@@ -0,0 +1,442 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+
+	"github.com/speier/tokenscout/internal/logger"
+	"github.com/speier/tokenscout/internal/models"
+	"github.com/speier/tokenscout/internal/solana"
+)
+
+// Built-in rules, registered in their default order. Cheap checks on the
+// mint account run before the RPC-heavy holder, pool and quote checks.
+func init() {
+	RegisterRule("blacklist", newBlacklistRule)
+	RegisterRule("deployer", newDeployerRule)
+	RegisterRule("freeze_authority", newFreezeAuthorityRule)
+	RegisterRule("mint_authority", newMintAuthorityRule)
+	RegisterRule("token_extensions", newTokenExtensionsRule)
+	RegisterRule("metadata", newMetadataRule)
+	RegisterRule("min_holders", newMinHoldersRule)
+	RegisterRule("top_holder", newTopHolderRule)
+	RegisterRule("mint_age", newMintAgeRule)
+	RegisterRule("min_liquidity", newMinLiquidityRule)
+	RegisterRule("lp_burned", newLPBurnedRule)
+	RegisterRule("honeypot", newHoneypotRule)
+}
+
+// funcRule adapts a check function to the Rule interface
+type funcRule struct {
+	name     string
+	severity RuleSeverity
+	inputs   []FactKind
+	check    func(ctx context.Context, facts *TokenFacts) RuleVerdict
+}
+
+// NewRule builds a Rule from a check function
+func NewRule(name string, severity RuleSeverity, inputs []FactKind, check func(ctx context.Context, facts *TokenFacts) RuleVerdict) Rule {
+	return &funcRule{
+		name:     name,
+		severity: severity,
+		inputs:   inputs,
+		check:    check,
+	}
+}
+
+func (r *funcRule) Name() string           { return r.name }
+func (r *funcRule) Inputs() []FactKind     { return r.inputs }
+func (r *funcRule) Severity() RuleSeverity { return r.severity }
+
+func (r *funcRule) Check(ctx context.Context, facts *TokenFacts) RuleVerdict {
+	return r.check(ctx, facts)
+}
+
+func newBlacklistRule(config *models.Config) Rule {
+	return NewRule("blacklist", SeverityCritical, nil, func(ctx context.Context, facts *TokenFacts) RuleVerdict {
+		blacklisted, err := facts.Repo().IsBlacklisted(ctx, facts.Mint())
+		if err != nil {
+			return RuleFail("failed to check blacklist")
+		}
+		if blacklisted {
+			return RuleFail("mint is blacklisted")
+		}
+		return RulePass()
+	})
+}
+
+func newDeployerRule(config *models.Config) Rule {
+	if !config.Rules.BlockBlacklistedDeployers {
+		return nil
+	}
+
+	// Serial ruggers fund each new wallet from the last one, so the funder
+	// is checked against the deployer blacklist too
+	return NewRule("deployer", SeverityCritical, []FactKind{FactDeployer}, func(ctx context.Context, facts *TokenFacts) RuleVerdict {
+		deployer, err := facts.Deployer(ctx)
+		if err != nil {
+			return RulePass() // Unknown deployer doesn't block the entry
+		}
+		for _, wallet := range []string{deployer.Creator, deployer.Funder} {
+			if wallet == "" {
+				continue
+			}
+			blacklisted, err := facts.Repo().IsDeployerBlacklisted(ctx, wallet)
+			if err != nil {
+				return RuleFail("failed to check deployer blacklist")
+			}
+			if blacklisted {
+				logger.Debug().
+					Str("mint", formatMint(facts.Mint())).
+					Str("wallet", formatMint(wallet)).
+					Bool("funder", wallet != deployer.Creator).
+					Msg("Blacklisted deployer")
+				return RuleFail("deployer blacklisted")
+			}
+		}
+		return RulePass()
+	})
+}
+
+// tokenInfoRule builds a critical rule over the mint account
+func tokenInfoRule(name string, check func(info *solana.TokenInfo) RuleVerdict) Rule {
+	return NewRule(name, SeverityCritical, []FactKind{FactTokenInfo}, func(ctx context.Context, facts *TokenFacts) RuleVerdict {
+		info, err := facts.TokenInfo(ctx)
+		if err != nil {
+			return RuleFail("failed to fetch token info")
+		}
+		return check(info)
+	})
+}
+
+func newFreezeAuthorityRule(config *models.Config) Rule {
+	if !config.Rules.BlockFreezeAuthority {
+		return nil
+	}
+	return tokenInfoRule("freeze_authority", func(info *solana.TokenInfo) RuleVerdict {
+		if info.HasFreezeAuthority {
+			return RuleFail("has freeze authority")
+		}
+		return RulePass()
+	})
+}
+
+func newMintAuthorityRule(config *models.Config) Rule {
+	if config.Rules.AllowMintAuthority {
+		return nil
+	}
+	return tokenInfoRule("mint_authority", func(info *solana.TokenInfo) RuleVerdict {
+		if info.HasMintAuthority {
+			return RuleFail("has mint authority")
+		}
+		return RulePass()
+	})
+}
+
+func newTokenExtensionsRule(config *models.Config) Rule {
+	rules := config.Rules
+	return tokenInfoRule("token_extensions", func(info *solana.TokenInfo) RuleVerdict {
+		ext := info.Extensions
+		if feePct := ext.TransferFeePct(); feePct > rules.MaxTransferFeePct {
+			return RuleFail("transfer fee: %.2f%% > %.2f%%", feePct, rules.MaxTransferFeePct)
+		}
+		if rules.BlockTransferHook && ext.TransferHook != nil {
+			return RuleFail("has transfer hook")
+		}
+		if rules.BlockPermanentDelegate && ext.PermanentDelegate != nil {
+			return RuleFail("has permanent delegate")
+		}
+		if rules.BlockDefaultFrozen && ext.DefaultFrozen {
+			return RuleFail("accounts frozen by default")
+		}
+		if rules.BlockNonTransferable && ext.NonTransferable {
+			return RuleFail("non-transferable")
+		}
+		return RulePass()
+	})
+}
+
+func newMetadataRule(config *models.Config) Rule {
+	rules := config.Rules
+	blocklist := compileNameBlocklist(rules.NameBlocklist)
+	if !rules.BlockMutableMetadata && !rules.BlockUpdateAuthority && len(blocklist) == 0 {
+		return nil
+	}
+
+	return tokenInfoRule("metadata", func(info *solana.TokenInfo) RuleVerdict {
+		if meta := info.Metadata; meta != nil {
+			if rules.BlockMutableMetadata && meta.IsMutable {
+				return RuleFail("metadata is mutable")
+			}
+			if rules.BlockUpdateAuthority && meta.HasLiveUpdateAuthority() {
+				return RuleFail("has metadata update authority")
+			}
+		}
+
+		for _, re := range blocklist {
+			if re.MatchString(info.Symbol) {
+				return RuleFail("blocklisted symbol: %s", info.Symbol)
+			}
+			if re.MatchString(info.Name) {
+				return RuleFail("blocklisted name: %s", info.Name)
+			}
+		}
+		return RulePass()
+	})
+}
+
+// compileNameBlocklist compiles the name/symbol patterns case-insensitively,
+// skipping (and warning about) any that don't parse
+func compileNameBlocklist(patterns []string) []*regexp.Regexp {
+	var compiled []*regexp.Regexp
+	for _, pattern := range patterns {
+		re, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			logger.Warn().Err(err).Str("pattern", pattern).Msg("⚠️  Ignoring invalid name_blocklist pattern")
+			continue
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled
+}
+
+func newMinHoldersRule(config *models.Config) Rule {
+	minHolders := config.Rules.MinHolders
+	if minHolders <= 0 {
+		return nil
+	}
+
+	// Holder count can increase
+	return NewRule("min_holders", SeverityWatch, []FactKind{FactHolders}, func(ctx context.Context, facts *TokenFacts) RuleVerdict {
+		holders, err := facts.Holders(ctx)
+		if err != nil {
+			return RuleFail("failed to fetch holders")
+		}
+		holderCount := len(holders.Holders)
+		if holderCount < minHolders {
+			return RuleFail("holders: %d < %d", holderCount, minHolders)
+		}
+		return RulePassScored(float64(holderCount) / float64(minHolders*4))
+	})
+}
+
+func newTopHolderRule(config *models.Config) Rule {
+	maxPct := config.Rules.DevWalletMaxPct
+	if maxPct <= 0 {
+		return nil
+	}
+
+	inputs := []FactKind{FactHolders}
+	if config.Rules.HolderClusterWindowSlots > 0 {
+		inputs = append(inputs, FactHolderClusters)
+	}
+
+	// A cluster of wallets funded by one source counts as a single holder
+	return NewRule("top_holder", SeverityCritical, inputs, func(ctx context.Context, facts *TokenFacts) RuleVerdict {
+		holders, err := facts.Holders(ctx)
+		if err != nil {
+			return RuleFail("failed to fetch holders")
+		}
+		topHolderPct := holders.TopHolderPct()
+		if topHolderPct > maxPct {
+			return RuleFail("top holder: %.1f%% > %.1f%%", topHolderPct, maxPct)
+		}
+
+		// Untraceable clusters leave the plain top holder check
+		clusters, _ := facts.HolderClusters(ctx)
+		if len(clusters) > 0 {
+			clusterPct := holders.Pct(clusters[0].Amount)
+			if clusterPct > maxPct {
+				return RuleFail("holder cluster: %.1f%% across %d wallets > %.1f%%", clusterPct, len(clusters[0].Wallets), maxPct)
+			}
+			topHolderPct = math.Max(topHolderPct, clusterPct)
+		}
+		return RulePassScored(1 - topHolderPct/maxPct)
+	})
+}
+
+func newMintAgeRule(config *models.Config) Rule {
+	maxAgeSec := config.Rules.MaxMintAgeSec
+	if maxAgeSec <= 0 {
+		return nil
+	}
+
+	return NewRule("mint_age", SeverityCritical, []FactKind{FactMintAge}, func(ctx context.Context, facts *TokenFacts) RuleVerdict {
+		age, err := facts.MintAge(ctx)
+		if err != nil {
+			return RulePass() // Unknown age doesn't block the entry
+		}
+		ageSeconds := int64(age.Seconds())
+		if ageSeconds > int64(maxAgeSec) {
+			return RuleFail("too old: %ds", ageSeconds)
+		}
+		return RulePassScored(1 - float64(ageSeconds)/float64(maxAgeSec))
+	})
+}
+
+func newMinLiquidityRule(config *models.Config) Rule {
+	minUSD := config.Rules.MinLiquidityUSD
+	if minUSD <= 0 {
+		return nil
+	}
+
+	// Raydium AMM V4 and Orca Whirlpool pools; liquidity can still arrive
+	return NewRule("min_liquidity", SeverityWatch, []FactKind{FactPool}, func(ctx context.Context, facts *TokenFacts) RuleVerdict {
+		reserves, err := facts.Pool(ctx)
+		if err != nil {
+			return RuleFail("liquidity: no priced pool found")
+		}
+
+		logger.Debug().
+			Str("mint", formatMint(facts.Mint())).
+			Str("pool", reserves.Pool.String()).
+			Float64("liquidity_usd", reserves.LiquidityUSD).
+			Msg("Pool liquidity")
+
+		if reserves.LiquidityUSD < minUSD {
+			return RuleFail("liquidity: $%.0f < $%.0f", reserves.LiquidityUSD, minUSD)
+		}
+		return RulePassScored(reserves.LiquidityUSD / (minUSD * 4))
+	})
+}
+
+func newLPBurnedRule(config *models.Config) Rule {
+	minPct := config.Rules.MinLPBurnedPct
+	if minPct <= 0 {
+		return nil
+	}
+
+	// Creators often burn or lock LP shortly after launch
+	return NewRule("lp_burned", SeverityWatch, []FactKind{FactPool}, func(ctx context.Context, facts *TokenFacts) RuleVerdict {
+		safePct, err := checkLPBurned(ctx, facts)
+		if err != nil {
+			return RuleFail("lp burned: %s", err.Error())
+		}
+		if safePct < minPct {
+			return RuleFail("lp burned: %.1f%% < %.1f%%", safePct, minPct)
+		}
+		return RulePass()
+	})
+}
+
+// checkLPBurned returns the share of the deepest pool's LP that is burned or
+// locked. Only Raydium AMM V4 pools have an LP mint to check.
+func checkLPBurned(ctx context.Context, facts *TokenFacts) (float64, error) {
+	reserves, err := facts.Pool(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("no priced pool found")
+	}
+	if !reserves.Program.Equals(solana.RaydiumAMMV4Program) {
+		return 0, fmt.Errorf("deepest pool has no LP mint")
+	}
+
+	// The listener read the LP mint from the pool's init instruction
+	lpInfo, err := solana.GetLPLockInfo(ctx, facts.RPC(), reserves.PoolVaults, facts.Event.LPMint)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read LP supply")
+	}
+
+	logger.Debug().
+		Str("mint", formatMint(facts.Mint())).
+		Str("lp_mint", lpInfo.LPMint.String()).
+		Uint64("issued", lpInfo.Issued).
+		Uint64("burned", lpInfo.Burned).
+		Uint64("locked", lpInfo.Locked).
+		Msg("LP burn/lock")
+
+	return lpInfo.SafePct(), nil
+}
+
+// HONEYPOT DETECTION: Quote a buy of the planned size and sell it straight back
+// This is CRITICAL for snipe & flip - many scam tokens allow buy but block sell,
+// or let you sell only after a heavy tax
+func newHoneypotRule(config *models.Config) Rule {
+	jupiterClient := solana.NewJupiterClient(config.Solana.JupiterAPIURL)
+	maxLossPct := config.Rules.MaxRoundTripLossPct
+	slippageBps := config.Trading.SlippageBps
+
+	return NewRule("honeypot", SeverityCritical, []FactKind{FactTradeSize}, func(ctx context.Context, facts *TokenFacts) RuleVerdict {
+		solAmount, err := facts.TradeSize(ctx)
+		if err != nil {
+			// Sizers never spend more than max_spend_per_trade, and impact
+			// only grows with size, so quote the worst case
+			logger.Debug().Err(err).Str("mint", formatMint(facts.Mint())).Msg("Failed to size trade, quoting max_spend_per_trade")
+			solAmount = config.Trading.MaxSpendPerTrade
+		}
+		if solAmount <= 0 {
+			solAmount = honeypotFallbackSOL
+		}
+
+		lossPct, err := checkRoundTrip(ctx, jupiterClient, facts.Mint(), solAmount, slippageBps)
+		if err != nil {
+			return RuleFail("honeypot detected: %s", err.Error())
+		}
+		if maxLossPct > 0 && lossPct > maxLossPct {
+			return RuleFail("round-trip loss: %.1f%% > %.1f%%", lossPct, maxLossPct)
+		}
+		return RulePass()
+	})
+}
+
+// honeypotFallbackSOL is the round-trip size when the trade size is unset
+const honeypotFallbackSOL = 0.01
+
+// checkRoundTrip quotes buying solAmount SOL of the token and selling the
+// tokens back, and returns the SOL lost on the way as a percentage. Pool fees
+// and price impact cost a few percent; a transfer tax or a sell-side fee
+// shows up as a much bigger loss.
+func checkRoundTrip(ctx context.Context, jupiterClient *solana.JupiterClient, mint string, solAmount float64, slippageBps int) (float64, error) {
+	logger.Debug().
+		Str("mint", formatMint(mint)).
+		Float64("size_sol", solAmount).
+		Msg("Checking for honeypot (quoting round trip)")
+
+	buyQuote, err := jupiterClient.GetQuote(ctx, buyQuoteRequest(mint, solAmount, slippageBps))
+	if err != nil {
+		return 0, fmt.Errorf("cannot get buy quote")
+	}
+	tokenAmount, err := strconv.ParseUint(buyQuote.OutAmount, 10, 64)
+	if err != nil || tokenAmount == 0 {
+		return 0, fmt.Errorf("invalid buy quote")
+	}
+
+	sellQuote, err := jupiterClient.GetQuote(ctx, solana.QuoteRequest{
+		InputMint:   mint,                                          // Token we want to sell
+		OutputMint:  "So11111111111111111111111111111111111111112", // SOL (wrapped)
+		Amount:      tokenAmount,
+		SlippageBps: slippageBps,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("cannot get sell quote (likely honeypot)")
+	}
+	if sellQuote == nil || sellQuote.OutAmount == "" {
+		return 0, fmt.Errorf("no sell route available (likely honeypot)")
+	}
+
+	solBack, err := strconv.ParseUint(sellQuote.OutAmount, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid sell quote")
+	}
+	if solBack == 0 {
+		return 0, fmt.Errorf("zero output on sell (likely honeypot)")
+	}
+
+	solIn, err := strconv.ParseUint(buyQuote.InAmount, 10, 64)
+	if err != nil || solIn == 0 {
+		solIn = uint64(solAmount * 1e9)
+	}
+	lossPct := (1 - float64(solBack)/float64(solIn)) * 100
+
+	logger.Debug().
+		Str("mint", formatMint(mint)).
+		Uint64("sol_in", solIn).
+		Uint64("token_amount", tokenAmount).
+		Uint64("sol_back", solBack).
+		Float64("loss_pct", lossPct).
+		Msg("✓ Round trip quoted (token is sellable)")
+
+	return lossPct, nil
+}
@@ -0,0 +1,86 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/speier/tokenscout/internal/models"
+	"github.com/speier/tokenscout/internal/solana"
+)
+
+func TestTradeSize(t *testing.T) {
+	config := &models.Config{Trading: models.TradingConfig{MaxSpendPerTrade: 0.5}}
+	tests := []struct {
+		name  string
+		sizer PositionSizer
+		want  float64
+	}{
+		{"no sizer", nil, 0.5},
+		{"manual amount", &fixedSizer{maxSOL: 0.2}, 0.2},
+		{"score sizing at full conviction", &scoreSizer{maxSOL: 0.5}, 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			facts := newTokenFacts(&models.Event{Mint: "mint"}, config, nil, nil, nil, tt.sizer)
+			got, err := facts.TradeSize(context.Background())
+			if err != nil {
+				t.Fatalf("TradeSize: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("TradeSize = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckRoundTrip(t *testing.T) {
+	var mu sync.Mutex
+	var requests []map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		mu.Lock()
+		requests = append(requests, map[string]string{
+			"amount":      query.Get("amount"),
+			"slippageBps": query.Get("slippageBps"),
+		})
+		mu.Unlock()
+
+		quote := solana.QuoteResponse{InAmount: query.Get("amount")}
+		if query.Get("inputMint") == "So11111111111111111111111111111111111111112" {
+			quote.OutAmount = "123456" // Tokens for the SOL in
+		} else {
+			quote.OutAmount = "180000000" // 0.18 SOL back for 0.2 in
+		}
+		json.NewEncoder(w).Encode(quote)
+	}))
+	defer server.Close()
+
+	lossPct, err := checkRoundTrip(context.Background(), solana.NewJupiterClient(server.URL), "mint", 0.2, 300)
+	if err != nil {
+		t.Fatalf("checkRoundTrip: %v", err)
+	}
+	if lossPct < 9.99 || lossPct > 10.01 {
+		t.Errorf("loss = %v%%, want 10%%", lossPct)
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("got %d quote requests, want 2", len(requests))
+	}
+	if got := requests[0]["amount"]; got != "200000000" {
+		t.Errorf("buy quote amount = %s lamports, want the 0.2 SOL trade size", got)
+	}
+	if got := requests[0]["slippageBps"]; got != "300" {
+		t.Errorf("buy quote slippage = %s bps, want the configured 300", got)
+	}
+	if got := requests[1]["amount"]; got != "123456" {
+		t.Errorf("sell quote amount = %s, want the bought 123456 tokens", got)
+	}
+	if got := requests[1]["slippageBps"]; got != "300" {
+		t.Errorf("sell quote slippage = %s bps, want the configured 300", got)
+	}
+}
@@ -0,0 +1,232 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/speier/tokenscout/internal/logger"
+	"github.com/speier/tokenscout/internal/models"
+	"github.com/speier/tokenscout/internal/solana"
+)
+
+// Exit reasons for rug signals
+const (
+	ExitRugLiquidityRemoved = "rug_liquidity_removed"
+	ExitRugHolderDump       = "rug_holder_dump"
+	ExitRugAuthorityChanged = "rug_authority_changed"
+)
+
+// DangerSignal is a reason to leave a position right away
+type DangerSignal struct {
+	Reason string // One of the rug exit reasons
+	Detail string
+}
+
+// DangerWatch checks held tokens for rug signals: liquidity pulled from the
+// pool, the top holder dumping, and mint/freeze authority appearing or
+// changing. Baselines are taken from the first successful read of each.
+type DangerWatch struct {
+	config    *models.Config
+	rpcClient *rpc.Client
+	wallet    string // Our own holdings are never the "top holder"
+
+	mu        sync.Mutex
+	baselines map[string]*dangerBaseline
+}
+
+type dangerBaseline struct {
+	checkedAt         time.Time
+	authoritiesSeeded bool
+	mintAuthority     string // "" when disabled
+	freezeAuthority   string
+	peakDepth         float64 // sqrt(solReserve * tokenReserve); swaps keep it, LP removal shrinks it
+	topHolder         string
+	topHolderAmount   uint64
+}
+
+func NewDangerWatch(config *models.Config, rpcURL string, wallet string) *DangerWatch {
+	return &DangerWatch{
+		config:    config,
+		rpcClient: rpc.New(rpcURL),
+		wallet:    wallet,
+		baselines: make(map[string]*dangerBaseline),
+	}
+}
+
+// Check runs the rug checks for a position at most once per configured
+// interval. It returns nil when nothing is wrong or the check was skipped.
+func (d *DangerWatch) Check(ctx context.Context, pos *models.Position) *DangerSignal {
+	interval := time.Duration(d.config.Risk.RugCheckIntervalSec) * time.Second
+	if interval <= 0 {
+		return nil
+	}
+
+	d.mu.Lock()
+	base, known := d.baselines[pos.Mint]
+	if !known {
+		base = &dangerBaseline{}
+		d.baselines[pos.Mint] = base
+	}
+	if time.Since(base.checkedAt) < interval {
+		d.mu.Unlock()
+		return nil
+	}
+	base.checkedAt = time.Now()
+	d.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if signal := d.checkAuthorities(ctx, pos, base); signal != nil {
+		return signal
+	}
+	if signal := d.checkLiquidity(ctx, pos, base); signal != nil {
+		return signal
+	}
+	return d.checkTopHolder(ctx, pos, base)
+}
+
+// Retain drops baselines for positions that are no longer open
+func (d *DangerWatch) Retain(mints map[string]bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for mint := range d.baselines {
+		if !mints[mint] {
+			delete(d.baselines, mint)
+		}
+	}
+}
+
+func (d *DangerWatch) checkAuthorities(ctx context.Context, pos *models.Position, base *dangerBaseline) *DangerSignal {
+	if !d.config.Risk.RugExitOnAuthorityChange {
+		return nil
+	}
+
+	info, err := solana.GetTokenInfo(ctx, d.rpcClient, pos.Mint)
+	if err != nil {
+		logger.Debug().Err(err).Str("mint", formatMint(pos.Mint)).Msg("Rug check: failed to fetch token info")
+		return nil
+	}
+
+	mintAuth, freezeAuth := "", ""
+	if info.MintAuthority != nil {
+		mintAuth = info.MintAuthority.String()
+	}
+	if info.FreezeAuthority != nil {
+		freezeAuth = info.FreezeAuthority.String()
+	}
+
+	if !base.authoritiesSeeded {
+		// First successful look; the entry rules already judged the initial
+		// authorities. A failed read must not leave an empty baseline behind.
+		base.authoritiesSeeded = true
+		base.mintAuthority = mintAuth
+		base.freezeAuthority = freezeAuth
+		return nil
+	}
+
+	if mintAuth != base.mintAuthority && mintAuth != "" {
+		return &DangerSignal{
+			Reason: ExitRugAuthorityChanged,
+			Detail: fmt.Sprintf("mint authority set to %s", mintAuth),
+		}
+	}
+	if freezeAuth != base.freezeAuthority && freezeAuth != "" {
+		return &DangerSignal{
+			Reason: ExitRugAuthorityChanged,
+			Detail: fmt.Sprintf("freeze authority set to %s", freezeAuth),
+		}
+	}
+
+	// Authorities being revoked is fine - just follow it
+	base.mintAuthority = mintAuth
+	base.freezeAuthority = freezeAuth
+	return nil
+}
+
+func (d *DangerWatch) checkLiquidity(ctx context.Context, pos *models.Position, base *dangerBaseline) *DangerSignal {
+	dropPct := d.config.Risk.RugLiquidityDropPct
+	if dropPct <= 0 || pos.PoolAddress == "" {
+		return nil
+	}
+
+	vaults, err := solana.GetPoolVaults(ctx, d.rpcClient, pos.PoolAddress)
+	if err != nil {
+		return nil // Unsupported pool type
+	}
+	solReserve, err := solana.GetTokenAccountAmount(ctx, d.rpcClient, vaults.BaseVault)
+	if err != nil {
+		return nil
+	}
+	tokenReserve, err := solana.GetTokenAccountAmount(ctx, d.rpcClient, vaults.QuoteVault)
+	if err != nil {
+		return nil
+	}
+
+	depth := math.Sqrt(float64(solReserve) * float64(tokenReserve))
+	if depth > base.peakDepth {
+		base.peakDepth = depth
+		return nil
+	}
+
+	lostPct := (1 - depth/base.peakDepth) * 100
+	if lostPct >= dropPct {
+		return &DangerSignal{
+			Reason: ExitRugLiquidityRemoved,
+			Detail: fmt.Sprintf("pool depth down %.1f%% from peak", lostPct),
+		}
+	}
+	return nil
+}
+
+func (d *DangerWatch) checkTopHolder(ctx context.Context, pos *models.Position, base *dangerBaseline) *DangerSignal {
+	dumpPct := d.config.Risk.RugHolderDumpPct
+	if dumpPct <= 0 {
+		return nil
+	}
+
+	holders, err := solana.GetTokenHolders(ctx, d.rpcClient, pos.Mint)
+	if err != nil {
+		logger.Debug().Err(err).Str("mint", formatMint(pos.Mint)).Msg("Rug check: failed to fetch holders")
+		return nil
+	}
+
+	// Pool vaults and burns aren't holders that can dump, and neither are we
+	dist := solana.GetHolderDistribution(ctx, d.rpcClient, holders, pos.PoolAddress)
+	byOwner := make(map[string]uint64, len(dist.Holders))
+	for _, h := range dist.Holders {
+		if h.Owner.String() == d.wallet {
+			continue
+		}
+		byOwner[h.Owner.String()] = h.Amount
+	}
+
+	if base.topHolder == "" {
+		for owner, amount := range byOwner {
+			if amount > base.topHolderAmount {
+				base.topHolder = owner
+				base.topHolderAmount = amount
+			}
+		}
+		return nil
+	}
+
+	current := byOwner[base.topHolder]
+	if current > base.topHolderAmount {
+		base.topHolderAmount = current
+		return nil
+	}
+
+	soldPct := (1 - float64(current)/float64(base.topHolderAmount)) * 100
+	if soldPct >= dumpPct {
+		return &DangerSignal{
+			Reason: ExitRugHolderDump,
+			Detail: fmt.Sprintf("top holder %s sold %.1f%% of their tokens", formatMint(base.topHolder), soldPct),
+		}
+	}
+	return nil
+}
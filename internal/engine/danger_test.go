@@ -0,0 +1,149 @@
+package engine
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/speier/tokenscout/internal/models"
+)
+
+// mintRead is one scripted answer for the mint account; nil authorities are disabled
+type mintRead struct {
+	fail            bool
+	mintAuthority   *solana.PublicKey
+	freezeAuthority *solana.PublicKey
+}
+
+// mintServer answers successive getMultipleAccounts calls with reads in order
+func mintServer(t *testing.T, reads []mintRead) *rpc.Client {
+	var mu sync.Mutex
+	call := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID json.RawMessage `json:"id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("bad request: %v", err)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if call >= len(reads) {
+			t.Errorf("unexpected mint read %d", call+1)
+			return
+		}
+		read := reads[call]
+		call++
+
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		if read.fail {
+			response["error"] = map[string]interface{}{"code": -32005, "message": "node is behind"}
+		} else {
+			data := make([]byte, 82)
+			data[45] = 1 // Initialized
+			if read.mintAuthority != nil {
+				data[0] = 1
+				copy(data[4:36], read.mintAuthority[:])
+			}
+			if read.freezeAuthority != nil {
+				data[46] = 1
+				copy(data[50:82], read.freezeAuthority[:])
+			}
+			mint := map[string]interface{}{
+				"lamports":   1,
+				"owner":      solana.TokenProgramID.String(),
+				"data":       []string{base64.StdEncoding.EncodeToString(data), "base64"},
+				"executable": false,
+				"rentEpoch":  0,
+			}
+			response["result"] = map[string]interface{}{"context": map[string]interface{}{"slot": 1}, "value": []interface{}{mint, nil}}
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+	return rpc.New(server.URL)
+}
+
+func TestDangerWatchAuthorities(t *testing.T) {
+	authority := solana.NewWallet().PublicKey()
+	attacker := solana.NewWallet().PublicKey()
+
+	tests := []struct {
+		name   string
+		reads  []mintRead
+		signal []bool // Whether each pass signals
+	}{
+		{
+			name:   "unchanged authorities",
+			reads:  []mintRead{{mintAuthority: &authority}, {mintAuthority: &authority}},
+			signal: []bool{false, false},
+		},
+		{
+			name:   "mint authority set after entry",
+			reads:  []mintRead{{}, {mintAuthority: &attacker}},
+			signal: []bool{false, true},
+		},
+		{
+			name:   "freeze authority changed",
+			reads:  []mintRead{{freezeAuthority: &authority}, {freezeAuthority: &attacker}},
+			signal: []bool{false, true},
+		},
+		{
+			name:   "re-setting a revoked authority",
+			reads:  []mintRead{{mintAuthority: &authority}, {}, {mintAuthority: &authority}},
+			signal: []bool{false, false, true},
+		},
+		{
+			name:   "a failed first read does not seed an empty baseline",
+			reads:  []mintRead{{fail: true}, {mintAuthority: &authority, freezeAuthority: &authority}, {mintAuthority: &authority, freezeAuthority: &authority}},
+			signal: []bool{false, false, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &models.Config{Risk: models.RiskConfig{RugExitOnAuthorityChange: true}}
+			watch := &DangerWatch{config: config, rpcClient: mintServer(t, tt.reads), baselines: make(map[string]*dangerBaseline)}
+			pos := &models.Position{Mint: solana.NewWallet().PublicKey().String()}
+			base := &dangerBaseline{}
+
+			for i, want := range tt.signal {
+				signal := watch.checkAuthorities(context.Background(), pos, base)
+				if got := signal != nil; got != want {
+					t.Errorf("pass %d signal = %+v, want signal: %v", i+1, signal, want)
+				}
+				if signal != nil && signal.Reason != ExitRugAuthorityChanged {
+					t.Errorf("pass %d reason = %s, want %s", i+1, signal.Reason, ExitRugAuthorityChanged)
+				}
+			}
+		})
+	}
+}
+
+func TestDangerWatchThrottle(t *testing.T) {
+	config := &models.Config{Risk: models.RiskConfig{RugCheckIntervalSec: 60, RugExitOnAuthorityChange: true}}
+	watch := &DangerWatch{config: config, rpcClient: mintServer(t, []mintRead{{}}), baselines: make(map[string]*dangerBaseline)}
+	pos := &models.Position{Mint: solana.NewWallet().PublicKey().String()}
+
+	// Only the first call reaches the chain within the interval
+	for i := 0; i < 3; i++ {
+		if signal := watch.Check(context.Background(), pos); signal != nil {
+			t.Errorf("Check %d = %+v, want nil", i+1, signal)
+		}
+	}
+	if base := watch.baselines[pos.Mint]; base == nil || !base.authoritiesSeeded {
+		t.Errorf("baseline = %+v, want seeded authorities", base)
+	}
+
+	watch.Retain(map[string]bool{})
+	if len(watch.baselines) != 0 {
+		t.Errorf("Retain kept %d baselines of closed positions", len(watch.baselines))
+	}
+}
@@ -0,0 +1,89 @@
+package engine
+
+import (
+	"context"
+	"time"
+
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/speier/tokenscout/internal/logger"
+	"github.com/speier/tokenscout/internal/models"
+	"github.com/speier/tokenscout/internal/repository"
+	"github.com/speier/tokenscout/internal/solana"
+)
+
+// ExitStopLoss is the exit reason of a plain stop-loss sell
+const ExitStopLoss = "stop_loss"
+
+// isDeployerOffense reports whether a closed position shows its deployer
+// rugged: a rug-signal exit, or a stop-loss within fastStopSec of entry
+func isDeployerOffense(entry *models.RealizedPnL, fastStopSec int) bool {
+	switch entry.ExitReason {
+	case ExitRugLiquidityRemoved, ExitRugHolderDump, ExitRugAuthorityChanged:
+		return true
+	case ExitStopLoss:
+		return fastStopSec > 0 && entry.HoldSec <= int64(fastStopSec)
+	}
+	return false
+}
+
+// recordDeployer returns the wallets that created and funded a mint, looking
+// them up on chain and storing them the first time
+func recordDeployer(ctx context.Context, repo repository.Repository, client *rpc.Client, mint string) (*models.MintDeployer, error) {
+	if deployer, err := repo.GetMintDeployer(ctx, mint); err == nil {
+		return deployer, nil
+	}
+
+	found, err := solana.GetDeployer(ctx, client, mint)
+	if err != nil {
+		return nil, err
+	}
+	deployer := &models.MintDeployer{
+		Mint:       mint,
+		Creator:    found.Creator,
+		Funder:     found.Funder,
+		DetectedAt: time.Now(),
+	}
+	if err := repo.SaveMintDeployer(ctx, deployer); err != nil {
+		logger.Error().Err(err).Str("mint", mint).Msg("Failed to record mint deployer")
+	}
+	return deployer, nil
+}
+
+// trackDeployer records the deployer of a token we just bought, so a rug exit
+// can be traced back whether or not the deployer rule ran (forced manual
+// buys, the rule in rules.disabled). block_blacklisted_deployers off turns
+// tracking off.
+func (e *Executor) trackDeployer(ctx context.Context, mint string) {
+	if !e.config.Rules.BlockBlacklistedDeployers {
+		return
+	}
+	if _, err := recordDeployer(ctx, e.repo, e.solanaClient.RPC(), mint); err != nil {
+		logger.Warn().Err(err).Str("mint", formatMint(mint)).Msg("Failed to record mint deployer")
+	}
+}
+
+// judgeDeployer blacklists the creator of a token whose position just closed
+// on a rug. Deployers are recorded when a position opens.
+func (e *Executor) judgeDeployer(ctx context.Context, entry *models.RealizedPnL) {
+	if !isDeployerOffense(entry, e.config.Rules.DeployerFastStopSec) {
+		return
+	}
+
+	deployer, err := e.repo.GetMintDeployer(ctx, entry.Mint)
+	if err != nil || deployer.Creator == "" {
+		logger.Debug().Str("mint", formatMint(entry.Mint)).Msg("Deployer unknown, nothing to blacklist")
+		return
+	}
+
+	if err := e.repo.BlacklistDeployer(ctx, deployer.Creator, entry.Mint, entry.ExitReason); err != nil {
+		logger.Error().Err(err).Str("deployer", deployer.Creator).Msg("Failed to blacklist deployer")
+		return
+	}
+
+	logger.Warn().
+		Str("deployer", formatMint(deployer.Creator)).
+		Str("mint", formatMint(entry.Mint)).
+		Str("reason", entry.ExitReason).
+		Int64("held_sec", entry.HoldSec).
+		Msg("🚫 Deployer blacklisted")
+}
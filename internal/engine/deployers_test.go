@@ -0,0 +1,77 @@
+package engine
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/speier/tokenscout/internal/models"
+	"github.com/speier/tokenscout/internal/repository"
+)
+
+func TestIsDeployerOffense(t *testing.T) {
+	tests := []struct {
+		reason  string
+		holdSec int64
+		want    bool
+	}{
+		{ExitRugLiquidityRemoved, 3600, true},
+		{ExitRugHolderDump, 3600, true},
+		{ExitRugAuthorityChanged, 3600, true},
+		{ExitStopLoss, 30, true},
+		{ExitStopLoss, 60, true},
+		{ExitStopLoss, 61, false},
+		{"take_profit", 5, false},
+		{"manual", 5, false},
+	}
+
+	for _, tt := range tests {
+		entry := &models.RealizedPnL{ExitReason: tt.reason, HoldSec: tt.holdSec}
+		if got := isDeployerOffense(entry, 60); got != tt.want {
+			t.Errorf("isDeployerOffense(%s after %ds) = %v, want %v", tt.reason, tt.holdSec, got, tt.want)
+		}
+	}
+
+	// A fast stop only counts when deployer_fast_stop_sec is set
+	if isDeployerOffense(&models.RealizedPnL{ExitReason: ExitStopLoss, HoldSec: 1}, 0) {
+		t.Error("stop-loss counted as an offense with deployer_fast_stop_sec off")
+	}
+}
+
+func TestRecordedDeployerIsJudged(t *testing.T) {
+	ctx := context.Background()
+	repo, err := repository.NewSQLite(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("NewSQLite: %v", err)
+	}
+	defer repo.Close()
+
+	stored := &models.MintDeployer{Mint: "mint", Creator: "creator", Funder: "funder", DetectedAt: time.Now()}
+	if err := repo.SaveMintDeployer(ctx, stored); err != nil {
+		t.Fatalf("SaveMintDeployer: %v", err)
+	}
+
+	// A recorded deployer is reused without going back to the chain
+	deployer, err := recordDeployer(ctx, repo, nil, "mint")
+	if err != nil {
+		t.Fatalf("recordDeployer: %v", err)
+	}
+	if deployer.Creator != "creator" || deployer.Funder != "funder" {
+		t.Errorf("recordDeployer = %+v, want the stored deployer", deployer)
+	}
+
+	// Tracking off never reaches the chain either
+	config := &models.Config{Rules: models.RulesConfig{DeployerFastStopSec: 60}}
+	executor := &Executor{config: config, repo: repo}
+	executor.trackDeployer(ctx, "other")
+
+	executor.judgeDeployer(ctx, &models.RealizedPnL{Mint: "mint", ExitReason: ExitRugLiquidityRemoved, HoldSec: 600})
+	blacklisted, err := repo.IsDeployerBlacklisted(ctx, "creator")
+	if err != nil {
+		t.Fatalf("IsDeployerBlacklisted: %v", err)
+	}
+	if !blacklisted {
+		t.Error("creator of a rugged position was not blacklisted")
+	}
+}
@@ -3,6 +3,7 @@ package engine
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
@@ -13,16 +14,32 @@ import (
 )
 
 type Status struct {
-	Running       bool   `json:"running"`
-	Mode          string `json:"mode"`
-	OpenPositions int    `json:"open_positions"`
-	TotalTrades   int    `json:"total_trades"`
+	Running       bool      `json:"running"`
+	Mode          string    `json:"mode"`
+	OpenPositions int       `json:"open_positions"`
+	TotalTrades   int       `json:"total_trades"`
+	Risk          RiskState `json:"risk"`
 }
 
 type Stats struct {
-	Status        Status  `json:"status"`
-	WalletBalance float64 `json:"wallet_balance"`
-	TodayPnL      float64 `json:"today_pnl"`
+	Status        Status            `json:"status"`
+	WalletBalance float64           `json:"wallet_balance"`
+	TodayPnL      float64           `json:"today_pnl"`
+	WeekPnL       float64           `json:"week_pnl"`
+	AllTimePnL    float64           `json:"all_time_pnl"`
+	Today         models.PnLSummary `json:"today"`
+	Week          models.PnLSummary `json:"week"`
+	AllTime       models.PnLSummary `json:"all_time"`
+}
+
+// PositionMark is an open position valued at the current price
+type PositionMark struct {
+	models.Position
+	PriceUSD         float64   `json:"price_usd"`
+	PriceSource      string    `json:"price_source,omitempty"`
+	PriceFetchedAt   time.Time `json:"price_fetched_at"`
+	UnrealizedPnLUSD float64   `json:"unrealized_pnl_usd"`
+	UnrealizedPnLPct float64   `json:"unrealized_pnl_pct"`
 }
 
 type Engine interface {
@@ -31,7 +48,11 @@ type Engine interface {
 	Status() Status
 	ExecuteTrade(ctx context.Context, trade *models.Trade) error
 	GetPositions(ctx context.Context) ([]models.Position, error)
+	GetPositionMarks(ctx context.Context) ([]PositionMark, error)
+	Buy(ctx context.Context, mint string, solAmount float64, checkRules bool) error
+	SellPosition(ctx context.Context, mint string, pct float64) error
 	ClosePosition(ctx context.Context, mint string) error
+	Reconcile(ctx context.Context, apply bool) (*ReconcileReport, error)
 	CloseAllPositions(ctx context.Context) error
 	GetConfig() *models.Config
 	UpdateConfig(cfg *models.Config) error
@@ -41,21 +62,27 @@ type Engine interface {
 }
 
 type engine struct {
-	repo      repository.Repository
-	config    *models.Config
-	status    Status
-	mu        sync.RWMutex
-	cancel    context.CancelFunc
-	listener  *Listener
-	processor *Processor
-	executor  *Executor
-	monitor   *Monitor
+	repo       repository.Repository
+	config     *models.Config
+	status     Status
+	mu         sync.RWMutex
+	cancel     context.CancelFunc
+	listener   *Listener
+	processor  *Processor
+	executor   *Executor
+	monitor    *Monitor
+	governor   *RiskGovernor
+	prices     *solana.PriceService
+	ruleEngine *RuleEngine
 }
 
 func New(repo repository.Repository, config *models.Config) Engine {
 	return &engine{
-		repo:   repo,
-		config: config,
+		repo:       repo,
+		config:     config,
+		governor:   NewRiskGovernor(config, repo),
+		prices:     solana.NewPriceService(solana.NewJupiterClient(config.Solana.JupiterAPIURL), solana.DefaultPriceTTL),
+		ruleEngine: NewRuleEngine(config, repo, config.Solana.RPCURL),
 		status: Status{
 			Running: false,
 			Mode:    string(config.Engine.Mode),
@@ -70,6 +97,12 @@ func (e *engine) Start(ctx context.Context) error {
 		return fmt.Errorf("engine already running")
 	}
 
+	// Bad expressions would reject every token; refuse to start instead
+	if err := validateExpressions(e.config.Rules.Expressions); err != nil {
+		e.mu.Unlock()
+		return fmt.Errorf("invalid rules.expressions: %w", err)
+	}
+
 	ctx, cancel := context.WithCancel(ctx)
 	e.cancel = cancel
 	e.status.Running = true
@@ -104,8 +137,20 @@ func (e *engine) Start(ctx context.Context) error {
 	solanaClient := solana.NewClient(e.config.Solana.RPCURL, wallet) // wallet can be nil
 	jupiterClient := solana.NewJupiterClient(e.config.Solana.JupiterAPIURL)
 
-	e.executor = NewExecutor(e.config, e.repo, solanaClient, jupiterClient)
-	e.monitor = NewMonitor(e.config, e.repo, e.executor, jupiterClient)
+	e.executor = NewExecutor(e.config, e.repo, solanaClient, jupiterClient, e.prices, e.governor)
+
+	// Settle trades a previous run left PENDING, then make sure the positions
+	// table matches the wallet before the monitor starts trading on it
+	if err := e.executor.RecoverPendingTrades(ctx); err != nil {
+		logger.Error().Err(err).Msg("Failed to recover pending trades")
+	}
+	if e.config.Engine.Mode == models.ModeLive && walletLoaded {
+		if _, err := e.reconcile(ctx, NewReconciler(e.repo, solanaClient), true); err != nil {
+			logger.Error().Err(err).Msg("Failed to reconcile positions with wallet")
+		}
+	}
+
+	e.monitor = NewMonitor(e.config, e.repo, e.executor, e.prices, e.governor)
 
 	// Start position monitor
 	go func() {
@@ -206,7 +251,9 @@ func (e *engine) Stop() error {
 func (e *engine) Status() Status {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
-	return e.status
+	status := e.status
+	status.Risk = e.governor.State()
+	return status
 }
 
 func (e *engine) ExecuteTrade(ctx context.Context, trade *models.Trade) error {
@@ -218,9 +265,147 @@ func (e *engine) GetPositions(ctx context.Context) ([]models.Position, error) {
 	return e.repo.GetAllPositions(ctx)
 }
 
+// GetPositionMarks values all open positions with one batched price request
+func (e *engine) GetPositionMarks(ctx context.Context) ([]PositionMark, error) {
+	positions, err := e.repo.GetAllPositions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mints := make(map[string]uint8, len(positions))
+	for _, pos := range positions {
+		mints[pos.Mint] = pos.Decimals
+	}
+	prices, err := e.prices.GetPrices(ctx, mints)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Failed to get prices")
+	}
+
+	marks := make([]PositionMark, 0, len(positions))
+	for _, pos := range positions {
+		mark := PositionMark{Position: pos}
+		if price, ok := prices[pos.Mint]; ok {
+			mark.PriceUSD = price.USD
+			mark.PriceSource = price.Source
+			mark.PriceFetchedAt = price.FetchedAt
+			if qty, err := strconv.ParseFloat(pos.Quantity, 64); err == nil {
+				mark.UnrealizedPnLUSD, mark.UnrealizedPnLPct = solana.CalculatePnL(pos.AvgPriceUSD, price.USD, qty)
+			}
+		}
+		marks = append(marks, mark)
+	}
+	return marks, nil
+}
+
+// manualReason tags trades placed by hand from the CLI
+const manualReason = "manual"
+
+// Buy opens a position by hand. With checkRules the token must pass the same
+// rules as automatic buys; a zero solAmount uses the configured sizing.
+func (e *engine) Buy(ctx context.Context, mint string, solAmount float64, checkRules bool) error {
+	executor, err := e.tradingExecutor()
+	if err != nil {
+		return err
+	}
+
+	score := 1.0
+	if checkRules {
+		// Rules judge the buy that will actually be placed
+		sizer := executor.sizer
+		if solAmount > 0 {
+			sizer = &fixedSizer{maxSOL: solAmount}
+		}
+		decision, err := e.ruleEngine.Evaluate(ctx, &models.Event{
+			Type:      models.EventTypeManual,
+			Mint:      mint,
+			Timestamp: time.Now(),
+		}, sizer)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate rules: %w", err)
+		}
+		if !decision.Allow {
+			return fmt.Errorf("rejected by rules: %s", decision.Reasons[0])
+		}
+		score = decision.Score
+	}
+
+	if solAmount > 0 {
+		return executor.ExecuteBuyAmount(ctx, mint, manualReason, solAmount)
+	}
+	return executor.ExecuteBuy(ctx, mint, manualReason, score)
+}
+
+// SellPosition sells pct percent of a position by hand
+func (e *engine) SellPosition(ctx context.Context, mint string, pct float64) error {
+	executor, err := e.tradingExecutor()
+	if err != nil {
+		return err
+	}
+	return executor.ExecutePartialSell(ctx, mint, pct, manualReason)
+}
+
+// ClosePosition sells the whole position
 func (e *engine) ClosePosition(ctx context.Context, mint string) error {
-	// TODO: Execute sell order
-	return e.repo.DeletePosition(ctx, mint)
+	return e.SellPosition(ctx, mint, 100)
+}
+
+// tradingExecutor returns the running engine's executor, or builds one for
+// one-off trades when the engine isn't running (e.g. from the CLI)
+func (e *engine) tradingExecutor() (*Executor, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.executor != nil {
+		return e.executor, nil
+	}
+
+	wallet, err := e.loadWallet()
+	if err != nil {
+		if e.config.Engine.Mode != models.ModeDryRun {
+			return nil, fmt.Errorf("failed to load wallet: %w", err)
+		}
+		wallet = nil // Dry-run can simulate without one
+	}
+
+	solanaClient := solana.NewClient(e.config.Solana.RPCURL, wallet)
+	jupiterClient := solana.NewJupiterClient(e.config.Solana.JupiterAPIURL)
+	e.executor = NewExecutor(e.config, e.repo, solanaClient, jupiterClient, e.prices, e.governor)
+	return e.executor, nil
+}
+
+// Reconcile compares open positions with the wallet's on-chain token
+// balances, and fixes the positions table when apply is set
+func (e *engine) Reconcile(ctx context.Context, apply bool) (*ReconcileReport, error) {
+	wallet, err := e.loadWallet()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load wallet: %w", err)
+	}
+	return e.reconcile(ctx, NewReconciler(e.repo, solana.NewClient(e.config.Solana.RPCURL, wallet)), apply)
+}
+
+func (e *engine) reconcile(ctx context.Context, reconciler *Reconciler, apply bool) (*ReconcileReport, error) {
+	report, err := reconciler.Diff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if report.InSync() {
+		logger.Debug().Int("positions", report.Positions).Msg("Positions match wallet balances")
+		return report, nil
+	}
+
+	logger.Info().
+		Int("positions", report.Positions).
+		Int("holdings", report.Holdings).
+		Int("mismatches", len(report.Diffs)).
+		Msg("🔍 Positions out of sync with wallet")
+
+	if apply {
+		if err := reconciler.Apply(ctx, report); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
 }
 
 func (e *engine) CloseAllPositions(ctx context.Context) error {
@@ -248,12 +433,21 @@ func (e *engine) UpdateConfig(cfg *models.Config) error {
 	defer e.mu.Unlock()
 	e.config = cfg
 	e.status.Mode = string(cfg.Engine.Mode)
+	e.ruleEngine.Reload(cfg)
 	return nil
 }
 
+// ReloadRules rebuilds the rules from the current config
 func (e *engine) ReloadRules() error {
-	// TODO: Reload rules from config or database
-	fmt.Println("Rules reloaded")
+	e.mu.RLock()
+	config := e.config
+	e.mu.RUnlock()
+
+	if err := validateExpressions(config.Rules.Expressions); err != nil {
+		return fmt.Errorf("invalid rules.expressions: %w", err)
+	}
+	e.ruleEngine.Reload(config)
+	logger.Info().Msg("🔄 Rules reloaded")
 	return nil
 }
 
@@ -268,19 +462,51 @@ func (e *engine) GetStats(ctx context.Context) (Stats, error) {
 		return Stats{}, err
 	}
 
-	e.mu.RLock()
-	status := e.status
-	e.mu.RUnlock()
+	status := e.Status()
+	if risk, err := e.governor.Refresh(ctx); err == nil {
+		status.Risk = risk
+	} else {
+		logger.Debug().Err(err).Msg("Failed to evaluate risk limits")
+	}
 
 	status.OpenPositions = len(positions)
 	status.TotalTrades = len(trades)
 
-	return Stats{
-		Status: status,
-		// TODO: Calculate actual wallet balance and PnL
-		WalletBalance: 0,
-		TodayPnL:      0,
-	}, nil
+	stats := Stats{Status: status}
+
+	// Realized PnL from the ledger of closed positions
+	now := time.Now()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	periods := []struct {
+		since time.Time
+		out   *models.PnLSummary
+	}{
+		{midnight, &stats.Today},
+		{now.AddDate(0, 0, -7), &stats.Week},
+		{time.Time{}, &stats.AllTime},
+	}
+	for _, p := range periods {
+		summary, err := e.repo.GetPnLSummary(ctx, p.since)
+		if err != nil {
+			return Stats{}, fmt.Errorf("failed to get pnl summary: %w", err)
+		}
+		*p.out = *summary
+	}
+	stats.TodayPnL = stats.Today.PnLUSD
+	stats.WeekPnL = stats.Week.PnLUSD
+	stats.AllTimePnL = stats.AllTime.PnLUSD
+
+	// Wallet balance is best-effort - status should still work offline
+	if wallet, err := e.loadWallet(); err == nil {
+		client := solana.NewClient(e.config.Solana.RPCURL, wallet)
+		if lamports, err := client.GetBalance(ctx); err == nil {
+			stats.WalletBalance = solana.ConvertLamportsToSOL(lamports)
+		} else {
+			logger.Debug().Err(err).Msg("Failed to fetch wallet balance")
+		}
+	}
+
+	return stats, nil
 }
 
 func (e *engine) GetRecentTrades(ctx context.Context, limit int) ([]models.Trade, error) {
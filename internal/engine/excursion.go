@@ -0,0 +1,77 @@
+package engine
+
+import (
+	"context"
+	"time"
+
+	"github.com/speier/tokenscout/internal/logger"
+	"github.com/speier/tokenscout/internal/models"
+)
+
+// minTickInterval limits how often a position's price is stored; the pool
+// stream can report many changes per second
+const minTickInterval = time.Second
+
+// recordTick stores a price observation for an open position, at most once
+// per minTickInterval per mint
+func (m *Monitor) recordTick(ctx context.Context, mint string, priceUSD float64, source string) {
+	now := time.Now()
+	if last, ok := m.lastTick[mint]; ok && now.Sub(last) < minTickInterval {
+		return
+	}
+	m.lastTick[mint] = now
+
+	tick := &models.PriceTick{
+		Mint:      mint,
+		Timestamp: now,
+		PriceUSD:  priceUSD,
+		Source:    source,
+	}
+	if err := m.repo.CreatePriceTick(ctx, tick); err != nil {
+		logger.Debug().Err(err).Str("mint", formatMint(mint)).Msg("Failed to record price tick")
+	}
+}
+
+// applyExcursions fills in MFE, MAE and time-to-peak on the ledger entry of a
+// closing position from its recorded price ticks plus the entry and exit prices
+func (e *Executor) applyExcursions(ctx context.Context, entry *models.RealizedPnL, position *models.Position, exitPriceUSD float64) {
+	ticks, err := e.repo.GetPriceTicks(ctx, position.Mint, position.OpenedAt)
+	if err != nil {
+		logger.Warn().Err(err).Str("mint", position.Mint).Msg("Failed to read price history")
+	}
+	if exitPriceUSD > 0 {
+		ticks = append(ticks, models.PriceTick{Timestamp: entry.ClosedAt, PriceUSD: exitPriceUSD})
+	}
+
+	mfe, mae, toPeak := excursions(position.AvgPriceUSD, position.OpenedAt, ticks)
+	entry.MFEPct = mfe
+	entry.MAEPct = mae
+	entry.TimeToPeakSec = int64(toPeak.Seconds())
+}
+
+// excursions returns the best and worst price seen relative to entry, in
+// percent (MFE >= 0, MAE <= 0), and how long after opening the best price came
+func excursions(entryPrice float64, openedAt time.Time, ticks []models.PriceTick) (mfePct, maePct float64, timeToPeak time.Duration) {
+	if entryPrice <= 0 {
+		return 0, 0, 0
+	}
+
+	peak, trough := entryPrice, entryPrice
+	peakAt := openedAt
+	for _, tick := range ticks {
+		if tick.PriceUSD <= 0 {
+			continue
+		}
+		if tick.PriceUSD > peak {
+			peak = tick.PriceUSD
+			peakAt = tick.Timestamp
+		}
+		if tick.PriceUSD < trough {
+			trough = tick.PriceUSD
+		}
+	}
+
+	mfePct = (peak - entryPrice) / entryPrice * 100
+	maePct = (trough - entryPrice) / entryPrice * 100
+	return mfePct, maePct, peakAt.Sub(openedAt)
+}
@@ -0,0 +1,78 @@
+package engine
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/speier/tokenscout/internal/models"
+	"github.com/speier/tokenscout/internal/repository"
+)
+
+func TestExcursions(t *testing.T) {
+	opened := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(sec int, price float64) models.PriceTick {
+		return models.PriceTick{Timestamp: opened.Add(time.Duration(sec) * time.Second), PriceUSD: price}
+	}
+
+	tests := []struct {
+		name     string
+		entry    float64
+		ticks    []models.PriceTick
+		mfe, mae float64
+		toPeak   time.Duration
+	}{
+		{"no ticks", 2, nil, 0, 0, 0},
+		{"up then down", 2, []models.PriceTick{at(10, 3), at(20, 1), at(30, 2.5)}, 50, -50, 10 * time.Second},
+		{"only down", 2, []models.PriceTick{at(10, 1.5), at(20, 1)}, 0, -50, 0},
+		{"first peak wins a tie", 2, []models.PriceTick{at(10, 4), at(20, 4)}, 100, 0, 10 * time.Second},
+		{"missing prices are skipped", 2, []models.PriceTick{at(10, 0), at(20, 2.5)}, 25, 0, 20 * time.Second},
+		{"no entry price", 0, []models.PriceTick{at(10, 3)}, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mfe, mae, toPeak := excursions(tt.entry, opened, tt.ticks)
+			if mfe != tt.mfe || mae != tt.mae || toPeak != tt.toPeak {
+				t.Errorf("excursions = %v%%, %v%%, %v, want %v%%, %v%%, %v", mfe, mae, toPeak, tt.mfe, tt.mae, tt.toPeak)
+			}
+		})
+	}
+}
+
+func TestApplyExcursions(t *testing.T) {
+	ctx := context.Background()
+	repo, err := repository.NewSQLite(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("NewSQLite: %v", err)
+	}
+	defer repo.Close()
+
+	opened := time.Now().Add(-time.Hour).Truncate(time.Second)
+	monitor := &Monitor{repo: repo, lastTick: make(map[string]time.Time)}
+
+	// Ticks closer together than minTickInterval are dropped
+	monitor.recordTick(ctx, "mint", 1, "stream")
+	monitor.recordTick(ctx, "mint", 0.5, "stream")
+	monitor.lastTick["mint"] = time.Now().Add(-minTickInterval)
+	monitor.recordTick(ctx, "mint", 1.5, "poll")
+
+	ticks, err := repo.GetPriceTicks(ctx, "mint", opened)
+	if err != nil {
+		t.Fatalf("GetPriceTicks: %v", err)
+	}
+	if len(ticks) != 2 {
+		t.Fatalf("recorded %d ticks, want 2", len(ticks))
+	}
+
+	// The exit price counts too, and here it is the peak
+	position := &models.Position{Mint: "mint", AvgPriceUSD: 1.25, OpenedAt: opened}
+	entry := &models.RealizedPnL{ClosedAt: opened.Add(2 * time.Hour)}
+	executor := &Executor{repo: repo}
+	executor.applyExcursions(ctx, entry, position, 2.5)
+
+	if entry.MFEPct != 100 || entry.MAEPct != -20 || entry.TimeToPeakSec != 7200 {
+		t.Errorf("excursions = %v%%, %v%% peaking after %ds, want 100%%, -20%% after 7200s", entry.MFEPct, entry.MAEPct, entry.TimeToPeakSec)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/gagliardetto/solana-go/rpc"
 	"github.com/speier/tokenscout/internal/logger"
 	"github.com/speier/tokenscout/internal/models"
 	"github.com/speier/tokenscout/internal/repository"
@@ -19,11 +20,22 @@ func formatMint(mint string) string {
 	return mint
 }
 
+// tokenSymbol is the metadata symbol of a token, or "" when it has none
+func tokenSymbol(info *solana.TokenInfo) string {
+	if info == nil || info.Metadata == nil {
+		return ""
+	}
+	return info.Metadata.Symbol
+}
+
 type Executor struct {
 	config        *models.Config
 	repo          repository.Repository
 	solanaClient  *solana.Client
 	jupiterClient *solana.JupiterClient
+	prices        *solana.PriceService
+	governor      *RiskGovernor
+	sizer         PositionSizer
 }
 
 func NewExecutor(
@@ -31,17 +43,36 @@ func NewExecutor(
 	repo repository.Repository,
 	solanaClient *solana.Client,
 	jupiterClient *solana.JupiterClient,
+	prices *solana.PriceService,
+	governor *RiskGovernor,
 ) *Executor {
 	return &Executor{
 		config:        config,
 		repo:          repo,
 		solanaClient:  solanaClient,
 		jupiterClient: jupiterClient,
+		prices:        prices,
+		governor:      governor,
+		sizer:         NewPositionSizer(config, solanaClient, jupiterClient),
+	}
+}
+
+// ExecuteBuy opens a new position by buying a token. The score (0-1) from the
+// rule engine is used by score-based position sizing.
+func (e *Executor) ExecuteBuy(ctx context.Context, mint string, reason string, score float64) error {
+	return e.executeBuy(ctx, mint, reason, score, 0)
+}
+
+// ExecuteBuyAmount buys a token for exactly solAmount SOL instead of sizing
+// the position. Every other entry check still applies.
+func (e *Executor) ExecuteBuyAmount(ctx context.Context, mint string, reason string, solAmount float64) error {
+	if solAmount <= 0 {
+		return fmt.Errorf("invalid buy amount: %.9f SOL", solAmount)
 	}
+	return e.executeBuy(ctx, mint, reason, 1, solAmount)
 }
 
-// ExecuteBuy opens a new position by buying a token
-func (e *Executor) ExecuteBuy(ctx context.Context, mint string, reason string) error {
+func (e *Executor) executeBuy(ctx context.Context, mint string, reason string, score float64, solAmount float64) error {
 	// Check if already have a position
 	existingPos, err := e.repo.GetPosition(ctx, mint)
 	if err == nil && existingPos != nil {
@@ -63,48 +94,86 @@ func (e *Executor) ExecuteBuy(ctx context.Context, mint string, reason string) e
 		return nil
 	}
 
+	// Decide how much SOL to spend
+	size := &Size{SOL: solAmount}
+	if solAmount <= 0 {
+		size, err = e.sizer.Size(ctx, mint, score)
+		if err != nil {
+			return fmt.Errorf("failed to size position: %w", err)
+		}
+	}
+	if size.SOL <= 0 || size.SOL < e.config.Trading.MinSpendPerTrade {
+		logger.Info().
+			Str("mint", formatMint(mint)).
+			Float64("size_sol", size.SOL).
+			Float64("min_sol", e.config.Trading.MinSpendPerTrade).
+			Msg("Position size below minimum, skipping buy")
+		return nil
+	}
+
+	// Portfolio circuit breakers (daily loss, exposure, loss streak)
+	allowed, err := e.governor.AllowEntry(ctx, size.SOL)
+	if err != nil {
+		return fmt.Errorf("failed to check risk limits: %w", err)
+	}
+	if !allowed {
+		logger.Info().
+			Str("mint", formatMint(mint)).
+			Str("reason", e.governor.State().PauseReason).
+			Msg("🛑 Entries paused by risk governor, skipping buy")
+		return nil
+	}
+
 	logger.Info().
 		Str("mint", formatMint(mint)).
 		Msg("💰 Preparing to buy...")
 	logger.Debug().
 		Str("mode", string(e.config.Engine.Mode)).
-		Float64("amount", e.config.Trading.MaxSpendPerTrade).
+		Str("sizing", string(e.config.Trading.SizingMode)).
+		Float64("amount", size.SOL).
+		Float64("score", score).
 		Msg("Buy order details")
 
+	// Token decimals are needed to turn raw quote amounts into prices; the
+	// trade is recorded either way so a failed lookup still shows up
+	tokenInfo, err := solana.GetTokenInfo(ctx, e.solanaClient.RPC(), mint)
+	symbol := tokenSymbol(tokenInfo)
+
 	// Create trade record
 	trade := &models.Trade{
 		Timestamp: time.Now(),
 		Side:      models.TradeSideBuy,
 		Mint:      mint,
-		Quantity:  fmt.Sprintf("%.9f", e.config.Trading.MaxSpendPerTrade),
+		Symbol:    symbol,
+		Quantity:  fmt.Sprintf("%.9f", size.SOL), // SOL to spend until the fill is known
 		Status:    models.TradeStatusPending,
 		Strategy:  e.config.Strategy,
+		Reason:    reason,
 	}
 
 	if err := e.repo.CreateTrade(ctx, trade); err != nil {
 		return fmt.Errorf("failed to create trade record: %w", err)
 	}
 
-	// Get real Jupiter quote (both dry-run and live modes)
-	jupiterClient := e.jupiterClient
-
-	// Convert SOL amount to lamports
-	solAmount := uint64(e.config.Trading.MaxSpendPerTrade * 1e9)
-
-	quoteReq := solana.QuoteRequest{
-		InputMint:        "So11111111111111111111111111111111111111112", // SOL
-		OutputMint:       mint,
-		Amount:           solAmount,
-		SlippageBps:      100, // 1% slippage
-		OnlyDirectRoutes: false,
+	if err != nil {
+		logger.Error().Err(err).Str("mint", mint).Msg("Failed to fetch token info")
+		if err := e.repo.UpdateTradeStatus(ctx, trade.ID, models.TradeStatusFailed, err.Error()); err != nil {
+			return fmt.Errorf("failed to update trade: %w", err)
+		}
+		return fmt.Errorf("failed to fetch token info: %w", err)
 	}
+	decimals := tokenInfo.Decimals
 
-	logger.Debug().
-		Str("mint", mint).
-		Uint64("sol_amount", solAmount).
-		Msg("Fetching Jupiter quote")
+	// Get real Jupiter quote (both dry-run and live modes), unless sizing already did
+	quote := size.Quote
+	if quote == nil {
+		logger.Debug().
+			Str("mint", mint).
+			Float64("sol_amount", size.SOL).
+			Msg("Fetching Jupiter quote")
 
-	quote, err := jupiterClient.GetQuote(ctx, quoteReq)
+		quote, err = e.jupiterClient.GetQuote(ctx, buyQuoteRequest(mint, size.SOL, e.config.Trading.SlippageBps))
+	}
 	if err != nil {
 		logger.Error().Err(err).Str("mint", mint).Msg("Failed to get Jupiter quote")
 		if err := e.repo.UpdateTradeStatus(ctx, trade.ID, models.TradeStatusFailed, err.Error()); err != nil {
@@ -114,32 +183,29 @@ func (e *Executor) ExecuteBuy(ctx context.Context, mint string, reason string) e
 	}
 
 	// Parse amounts to calculate real price
-	inAmountFloat, err := strconv.ParseFloat(quote.InAmount, 64)
+	lamportsIn, err := strconv.ParseUint(quote.InAmount, 10, 64)
 	if err != nil {
 		return fmt.Errorf("failed to parse input amount: %w", err)
 	}
-	outAmountFloat, err := strconv.ParseFloat(quote.OutAmount, 64)
+	rawOut, err := strconv.ParseUint(quote.OutAmount, 10, 64)
 	if err != nil {
 		return fmt.Errorf("failed to parse output amount: %w", err)
 	}
 
 	// Calculate token quantity (accounting for decimals)
-	tokenQuantity := outAmountFloat / 1e9 // Assuming 9 decimals, should fetch from token mint
+	tokenQuantity := solana.ToUIAmount(rawOut, decimals)
 
 	// Get SOL/USD price to calculate token price in USD
-	solPrice, err := solana.GetSOLPrice(ctx)
-	if err != nil {
-		logger.Warn().Err(err).Msg("Failed to get SOL price, using $100 fallback")
-		solPrice = 100.0
-	}
+	solPrice := e.solPriceUSD(ctx)
 
 	// Calculate real token price in USD
-	solSpent := inAmountFloat / 1e9
+	solSpent := solana.ConvertLamportsToSOL(lamportsIn)
 	usdSpent := solSpent * solPrice
 	tokenPriceUSD := usdSpent / tokenQuantity
 
 	logger.Info().
 		Str("mint", formatMint(mint)).
+		Str("symbol", symbol).
 		Float64("price_usd", tokenPriceUSD).
 		Float64("tokens", tokenQuantity).
 		Msg("💵 Quote received")
@@ -149,6 +215,7 @@ func (e *Executor) ExecuteBuy(ctx context.Context, mint string, reason string) e
 		Msg("Quote details")
 
 	// In dry-run mode, don't execute but use real prices
+	var feeLamports uint64
 	if e.config.Engine.Mode == models.ModeDryRun {
 		logger.Info().
 			Str("mint", formatMint(mint)).
@@ -159,49 +226,188 @@ func (e *Executor) ExecuteBuy(ctx context.Context, mint string, reason string) e
 		if err := e.repo.UpdateTradeStatus(ctx, trade.ID, models.TradeStatusExecuted, "DRY_RUN"); err != nil {
 			return fmt.Errorf("failed to update trade: %w", err)
 		}
+	} else {
+		// Live mode - execute actual trade with the quote we already have
+		logger.Info().
+			Str("mint", formatMint(mint)).
+			Msg("🔴 LIVE: Executing buy via Jupiter")
 
-		// Create position with REAL price from Jupiter quote
-		position := &models.Position{
-			Mint:         mint,
-			Quantity:     fmt.Sprintf("%.9f", tokenQuantity),
-			AvgPriceUSD:  tokenPriceUSD, // REAL price from quote
-			OpenedAt:     time.Now(),
-			LastUpdateAt: time.Now(),
-			Strategy:     e.config.Strategy,
+		feeLamports, err = e.executeSwap(ctx, trade, quote)
+		if err != nil {
+			return fmt.Errorf("buy swap failed: %w", err)
 		}
 
-		if err := e.repo.CreatePosition(ctx, position); err != nil {
-			return fmt.Errorf("failed to create position: %w", err)
+		// Use what the swap actually delivered rather than the quoted amount.
+		// The wallet's total balance would also count tokens it already held.
+		received, err := e.swapTokenChange(ctx, trade.TxSig, mint)
+		if err != nil {
+			logger.Warn().Err(err).Str("mint", mint).Msg("Failed to read swap token change, using quoted amount")
+		} else if received > 0 {
+			rawOut = uint64(received)
+			tokenQuantity = solana.ToUIAmount(rawOut, decimals)
+			tokenPriceUSD = usdSpent / tokenQuantity
 		}
+	}
 
-		logger.Info().
-			Str("mint", formatMint(mint)).
-			Float64("entry_price", tokenPriceUSD).
-			Msg("📈 Position opened")
+	if err := e.repo.UpdateTradeFill(ctx, trade.ID, solana.FormatUIAmount(rawOut, decimals), strconv.FormatUint(rawOut, 10), tokenPriceUSD, 0); err != nil {
+		return fmt.Errorf("failed to update trade: %w", err)
+	}
 
-		return nil
+	// Create position with REAL price from Jupiter quote
+	position := &models.Position{
+		Mint:         mint,
+		Symbol:       symbol,
+		Quantity:     solana.FormatUIAmount(rawOut, decimals),
+		RawAmount:    strconv.FormatUint(rawOut, 10),
+		InitialRaw:   strconv.FormatUint(rawOut, 10),
+		Decimals:     decimals,
+		AvgPriceUSD:  tokenPriceUSD, // REAL price from quote
+		HighWaterUSD: tokenPriceUSD,
+		CostSOL:      solSpent,
+		CostUSD:      usdSpent,
+		FeesSOL:      solana.ConvertLamportsToSOL(feeLamports),
+		PoolAddress:  singleHopPool(quote),
+		OpenedAt:     time.Now(),
+		LastUpdateAt: time.Now(),
+		Strategy:     e.config.Strategy,
+	}
+
+	if err := e.repo.CreatePosition(ctx, position); err != nil {
+		return fmt.Errorf("failed to create position: %w", err)
 	}
 
-	// Live mode - execute actual trade with the quote we already have
 	logger.Info().
-		Str("mint", mint).
-		Msg("LIVE: Executing real buy via Jupiter")
-
-	// TODO: Execute swap transaction
-	// swapReq := solana.SwapRequest{
-	// 	QuoteResponse:    *quote,
-	// 	UserPublicKey:    wallet.PublicKey().String(),
-	// 	WrapAndUnwrapSol: true,
-	// }
-	//
-	// swapResp, err := jupiterClient.GetSwap(ctx, swapReq)
-	// ... sign and send transaction ...
-
-	logger.Warn().Msg("LIVE: Swap execution not yet implemented")
-	return fmt.Errorf("live trading not yet implemented")
+		Str("mint", formatMint(mint)).
+		Str("symbol", symbol).
+		Float64("entry_price", tokenPriceUSD).
+		Msg("📈 Position opened")
+
+	e.trackDeployer(ctx, mint)
+	return nil
 }
 
-// Helper to parse price impact percentage
+// executeSwap signs and sends the swap for an already-fetched quote and waits
+// for it to land on chain. The trade row gets the signature as soon as it is
+// known, then is marked EXECUTED or FAILED based on the on-chain outcome.
+// Returns the network fee paid in lamports (0 if it could not be read).
+func (e *Executor) executeSwap(ctx context.Context, trade *models.Trade, quote *solana.QuoteResponse) (uint64, error) {
+	tx, lastValidBlockHeight, err := e.jupiterClient.BuildSwapTransaction(ctx, e.solanaClient, quote, e.config.Trading.PriorityFeeMicroLamports)
+	if err != nil {
+		logger.Error().Err(err).Str("mint", trade.Mint).Msg("Failed to build swap")
+		if err := e.repo.UpdateTradeStatus(ctx, trade.ID, models.TradeStatusFailed, err.Error()); err != nil {
+			return 0, fmt.Errorf("failed to update trade: %w", err)
+		}
+		return 0, err
+	}
+
+	txSig := tx.Signatures[0].String()
+	trade.TxSig = txSig
+
+	// Record the signature before sending so a pending trade can always be traced on chain
+	if err := e.repo.UpdateTradeStatus(ctx, trade.ID, models.TradeStatusPending, txSig); err != nil {
+		return 0, fmt.Errorf("failed to update trade: %w", err)
+	}
+
+	logger.Info().
+		Str("mint", formatMint(trade.Mint)).
+		Str("tx", txSig).
+		Msg("📤 Sending swap, waiting for confirmation")
+
+	result, err := e.solanaClient.SendAndConfirm(ctx, tx, lastValidBlockHeight, rpc.CommitmentConfirmed)
+	if err != nil {
+		// Outcome unknown - leave the trade PENDING with its signature
+		logger.Error().Err(err).Str("tx", txSig).Msg("Swap outcome unknown")
+		return 0, err
+	}
+
+	if result.Outcome != solana.TxConfirmed {
+		logger.Error().
+			Err(result.Err()).
+			Str("tx", txSig).
+			Str("outcome", string(result.Outcome)).
+			Int("sends", result.Sends).
+			Msg("Swap did not land")
+		if err := e.repo.UpdateTradeStatus(ctx, trade.ID, models.TradeStatusFailed, txSig); err != nil {
+			return 0, fmt.Errorf("failed to update trade: %w", err)
+		}
+		return 0, result.Err()
+	}
+
+	if err := e.repo.UpdateTradeStatus(ctx, trade.ID, models.TradeStatusExecuted, txSig); err != nil {
+		return 0, fmt.Errorf("failed to update trade: %w", err)
+	}
+
+	logger.Info().
+		Str("mint", formatMint(trade.Mint)).
+		Str("tx", txSig).
+		Uint64("slot", result.Slot).
+		Msg("✅ Swap confirmed")
+
+	fee, err := e.solanaClient.GetTransactionFee(ctx, result.Signature)
+	if err != nil {
+		logger.Debug().Err(err).Str("tx", txSig).Msg("Failed to read transaction fee")
+		return 0, nil
+	}
+
+	return fee, nil
+}
+
+// swapTokenChange is how many raw tokens a confirmed swap moved into the
+// wallet (negative when it moved them out)
+func (e *Executor) swapTokenChange(ctx context.Context, txSig, mint string) (int64, error) {
+	sig, err := solana.ParseSignature(txSig)
+	if err != nil {
+		return 0, err
+	}
+	return e.solanaClient.GetTransactionTokenChange(ctx, sig, mint)
+}
+
+// swapSOLChange is how many lamports a confirmed swap paid the wallet before
+// the network fee (negative when it spent them), and that fee
+func (e *Executor) swapSOLChange(ctx context.Context, txSig string) (int64, uint64, error) {
+	sig, err := solana.ParseSignature(txSig)
+	if err != nil {
+		return 0, 0, err
+	}
+	change, feeLamports, err := e.solanaClient.GetTransactionSOLChange(ctx, sig)
+	if err != nil {
+		return 0, 0, err
+	}
+	return change + int64(feeLamports), feeLamports, nil
+}
+
+// solPriceUSD reads SOL/USD from the shared price service, warning when it had
+// to fall back to a stale or default value
+func (e *Executor) solPriceUSD(ctx context.Context) float64 {
+	price := e.prices.SOLPrice(ctx)
+	if price.Source == "fallback" {
+		logger.Warn().Msg("Failed to get SOL price, using $100 fallback")
+	} else if price.Age() > time.Minute {
+		logger.Warn().Dur("age", price.Age()).Msg("SOL price is stale")
+	}
+	return price.USD
+}
+
+// buyQuoteRequest builds the SOL -> token quote for a buy of solAmount SOL
+func buyQuoteRequest(mint string, solAmount float64, slippageBps int) solana.QuoteRequest {
+	return solana.QuoteRequest{
+		InputMint:        "So11111111111111111111111111111111111111112", // SOL
+		OutputMint:       mint,
+		Amount:           uint64(solAmount * 1e9),
+		SlippageBps:      slippageBps,
+		OnlyDirectRoutes: false,
+	}
+}
+
+// singleHopPool returns the AMM a quote routes through when it uses exactly one pool
+func singleHopPool(quote *solana.QuoteResponse) string {
+	if len(quote.RoutePlan) != 1 {
+		return ""
+	}
+	return quote.RoutePlan[0].SwapInfo.AmmKey
+}
+
+// parsePriceImpact parses a quote's price impact percentage, 0 if invalid
 func parsePriceImpact(impact string) float64 {
 	val, err := strconv.ParseFloat(impact, 64)
 	if err != nil {
@@ -212,6 +418,19 @@ func parsePriceImpact(impact string) float64 {
 
 // ExecuteSell closes a position by selling a token
 func (e *Executor) ExecuteSell(ctx context.Context, mint string, reason string) error {
+	return e.executeSell(ctx, mint, 100, reason)
+}
+
+// ExecutePartialSell sells pct percent of the current position and keeps the
+// rest open. Selling 100% or more closes the position.
+func (e *Executor) ExecutePartialSell(ctx context.Context, mint string, pct float64, reason string) error {
+	if pct <= 0 {
+		return fmt.Errorf("invalid sell percentage: %.2f", pct)
+	}
+	return e.executeSell(ctx, mint, pct, reason)
+}
+
+func (e *Executor) executeSell(ctx context.Context, mint string, pct float64, reason string) error {
 	// Get position
 	position, err := e.repo.GetPosition(ctx, mint)
 	if err != nil {
@@ -221,17 +440,55 @@ func (e *Executor) ExecuteSell(ctx context.Context, mint string, reason string)
 	logger.Info().
 		Str("mint", mint).
 		Str("reason", reason).
+		Float64("pct", pct).
 		Str("mode", string(e.config.Engine.Mode)).
 		Msg("Executing SELL order")
 
+	// Exact amount to sell in base units
+	positionUnits, err := positionRawAmount(position)
+	if err != nil {
+		return err
+	}
+
+	tokenUnits := positionUnits
+	if pct < 100 {
+		tokenUnits = uint64(float64(positionUnits) * pct / 100)
+	}
+
+	// In live mode never try to sell more than the wallet actually holds
+	walletDrained := false
+	if e.config.Engine.Mode != models.ModeDryRun {
+		held, err := e.solanaClient.GetTokenBalance(ctx, mint)
+		if err != nil {
+			logger.Warn().Err(err).Str("mint", mint).Msg("Failed to read token balance, using position amount")
+		} else if held < tokenUnits {
+			logger.Warn().
+				Str("mint", formatMint(mint)).
+				Uint64("position", tokenUnits).
+				Uint64("wallet", held).
+				Msg("Wallet holds less than position, selling wallet balance")
+			tokenUnits = held
+			walletDrained = true
+		}
+	}
+
+	if tokenUnits == 0 {
+		return fmt.Errorf("nothing to sell for %s", mint)
+	}
+
+	closing := pct >= 100 || tokenUnits >= positionUnits || walletDrained
+
 	// Create trade record
 	trade := &models.Trade{
 		Timestamp: time.Now(),
 		Side:      models.TradeSideSell,
 		Mint:      mint,
-		Quantity:  position.Quantity,
+		Symbol:    position.Symbol,
+		Quantity:  solana.FormatUIAmount(tokenUnits, position.Decimals),
+		RawAmount: strconv.FormatUint(tokenUnits, 10),
 		Status:    models.TradeStatusPending,
 		Strategy:  position.Strategy, // Use strategy from the position
+		Reason:    reason,
 	}
 
 	if err := e.repo.CreateTrade(ctx, trade); err != nil {
@@ -241,20 +498,11 @@ func (e *Executor) ExecuteSell(ctx context.Context, mint string, reason string)
 	// Get real Jupiter quote for selling
 	jupiterClient := e.jupiterClient
 
-	// Parse token quantity
-	tokenAmount, err := strconv.ParseFloat(position.Quantity, 64)
-	if err != nil {
-		return fmt.Errorf("failed to parse quantity: %w", err)
-	}
-
-	// Convert to token units (assuming 9 decimals)
-	tokenUnits := uint64(tokenAmount * 1e9)
-
 	quoteReq := solana.QuoteRequest{
 		InputMint:        mint,
 		OutputMint:       "So11111111111111111111111111111111111111112", // SOL
 		Amount:           tokenUnits,
-		SlippageBps:      100, // 1% slippage
+		SlippageBps:      e.config.Trading.SlippageBps,
 		OnlyDirectRoutes: false,
 	}
 
@@ -273,18 +521,14 @@ func (e *Executor) ExecuteSell(ctx context.Context, mint string, reason string)
 	}
 
 	// Calculate SOL received
-	outAmountFloat, err := strconv.ParseFloat(quote.OutAmount, 64)
+	lamportsOut, err := strconv.ParseUint(quote.OutAmount, 10, 64)
 	if err != nil {
 		return fmt.Errorf("failed to parse output amount: %w", err)
 	}
-	solReceived := outAmountFloat / 1e9
+	solReceived := solana.ConvertLamportsToSOL(lamportsOut)
 
 	// Get SOL price
-	solPrice, err := solana.GetSOLPrice(ctx)
-	if err != nil {
-		logger.Warn().Err(err).Msg("Failed to get SOL price, using $100 fallback")
-		solPrice = 100.0
-	}
+	solPrice := e.solPriceUSD(ctx)
 
 	usdReceived := solReceived * solPrice
 
@@ -296,6 +540,7 @@ func (e *Executor) ExecuteSell(ctx context.Context, mint string, reason string)
 		Msg("Real sell quote received from Jupiter")
 
 	// In dry-run mode, don't execute but use real prices
+	var feeLamports uint64
 	if e.config.Engine.Mode == models.ModeDryRun {
 		logger.Info().
 			Str("mint", mint).
@@ -306,28 +551,124 @@ func (e *Executor) ExecuteSell(ctx context.Context, mint string, reason string)
 		if err := e.repo.UpdateTradeStatus(ctx, trade.ID, models.TradeStatusExecuted, "DRY_RUN"); err != nil {
 			return fmt.Errorf("failed to update trade: %w", err)
 		}
+	} else {
+		// Live mode - execute actual trade with the quote we already have
+		logger.Info().
+			Str("mint", formatMint(mint)).
+			Str("reason", reason).
+			Msg("🔴 LIVE: Executing sell via Jupiter")
+
+		feeLamports, err = e.executeSwap(ctx, trade, quote)
+		if err != nil {
+			return fmt.Errorf("sell swap failed: %w", err)
+		}
+
+		// Book what the swap actually paid out rather than the quote
+		received, _, err := e.swapSOLChange(ctx, trade.TxSig)
+		if err != nil {
+			logger.Warn().Err(err).Str("mint", mint).Msg("Failed to read swap SOL change, using quoted amount")
+		} else if received > 0 {
+			solReceived = solana.ConvertLamportsToSOL(uint64(received))
+			usdReceived = solReceived * solPrice
+		}
+	}
+
+	// Realized PnL for this tranche against the position's entry price
+	soldQty := solana.ToUIAmount(tokenUnits, position.Decimals)
+	exitPriceUSD := usdReceived / soldQty
+	pnlUSD := usdReceived - position.AvgPriceUSD*soldQty
+	if err := e.repo.UpdateTradeFill(ctx, trade.ID, trade.Quantity, trade.RawAmount, exitPriceUSD, pnlUSD); err != nil {
+		return fmt.Errorf("failed to update trade: %w", err)
+	}
 
-		// Delete position
-		if err := e.repo.DeletePosition(ctx, mint); err != nil {
-			return fmt.Errorf("failed to delete position: %w", err)
+	position.ProceedsSOL += solReceived
+	position.ProceedsUSD += usdReceived
+	position.FeesSOL += solana.ConvertLamportsToSOL(feeLamports)
+
+	if !closing {
+		remaining := positionUnits - tokenUnits
+		position.RawAmount = strconv.FormatUint(remaining, 10)
+		position.Quantity = solana.FormatUIAmount(remaining, position.Decimals)
+		position.LastUpdateAt = time.Now()
+		if err := e.repo.UpdatePosition(ctx, position); err != nil {
+			return fmt.Errorf("failed to update position: %w", err)
 		}
 
 		logger.Info().
-			Str("mint", mint).
+			Str("mint", formatMint(mint)).
+			Str("symbol", position.Symbol).
 			Float64("usd_received", usdReceived).
-			Msg("DRY RUN: Position closed with real sell price")
+			Float64("pnl_usd", pnlUSD).
+			Str("remaining", position.Quantity).
+			Msg("📉 Position reduced")
 
 		return nil
 	}
 
-	// Live mode - execute actual trade
+	// Record realized PnL for the whole position before it disappears
+	entry := newRealizedPnL(position, solPrice, reason)
+	e.applyExcursions(ctx, entry, position, exitPriceUSD)
+	if err := e.repo.CreateRealizedPnL(ctx, entry); err != nil {
+		logger.Error().Err(err).Str("mint", mint).Msg("Failed to record realized PnL")
+	}
+	e.judgeDeployer(ctx, entry)
+
+	// Delete position
+	if err := e.repo.DeletePosition(ctx, mint); err != nil {
+		return fmt.Errorf("failed to delete position: %w", err)
+	}
+
 	logger.Info().
 		Str("mint", mint).
-		Msg("LIVE: Executing real sell via Jupiter")
+		Str("symbol", position.Symbol).
+		Float64("usd_received", usdReceived).
+		Float64("pnl_usd", entry.PnLUSD).
+		Float64("pnl_sol", entry.PnLSOL).
+		Dur("held", entry.ClosedAt.Sub(entry.OpenedAt)).
+		Msg("📉 Position closed")
+
+	return nil
+}
 
-	// TODO: Execute swap transaction
-	logger.Warn().Msg("LIVE: Sell swap execution not yet implemented")
-	return fmt.Errorf("live trading not yet implemented")
+// newRealizedPnL builds the ledger entry for a fully closed position
+func newRealizedPnL(position *models.Position, solPrice float64, reason string) *models.RealizedPnL {
+	closedAt := time.Now()
+	feesUSD := position.FeesSOL * solPrice
+
+	return &models.RealizedPnL{
+		Mint:            position.Mint,
+		Strategy:        position.Strategy,
+		OpenedAt:        position.OpenedAt,
+		ClosedAt:        closedAt,
+		HoldSec:         int64(closedAt.Sub(position.OpenedAt).Seconds()),
+		EntryCostSOL:    position.CostSOL,
+		EntryCostUSD:    position.CostUSD,
+		ExitProceedsSOL: position.ProceedsSOL,
+		ExitProceedsUSD: position.ProceedsUSD,
+		FeesSOL:         position.FeesSOL,
+		FeesUSD:         feesUSD,
+		PnLSOL:          position.ProceedsSOL - position.CostSOL - position.FeesSOL,
+		PnLUSD:          position.ProceedsUSD - position.CostUSD - feesUSD,
+		ExitReason:      reason,
+	}
+}
+
+// positionRawAmount returns the position size in base units. Positions opened
+// before raw amounts were stored fall back to the UI quantity.
+func positionRawAmount(position *models.Position) (uint64, error) {
+	if position.RawAmount != "" {
+		raw, err := strconv.ParseUint(position.RawAmount, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse raw amount: %w", err)
+		}
+		return raw, nil
+	}
+
+	qty, err := strconv.ParseFloat(position.Quantity, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse quantity: %w", err)
+	}
+	return solana.ToRawAmount(qty, position.Decimals), nil
 }
 
 // SellAll closes all open positions
@@ -0,0 +1,453 @@
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A tiny boolean expression language for rules.expressions, e.g.
+//
+//	holders >= 20 && top10_pct < 60 && age_sec < 600
+//
+// Operands are numbers, true/false and fact variables. Operators, loosest
+// binding first: || (or), && (and), ! (not), comparisons (== != < <= > >=),
+// + -, * /, unary minus. Expressions are type-checked when compiled, so a
+// typo fails at startup instead of on the first token.
+
+type exprType int
+
+const (
+	exprNumber exprType = iota
+	exprBool
+)
+
+func (t exprType) String() string {
+	if t == exprBool {
+		return "bool"
+	}
+	return "number"
+}
+
+// exprLookup resolves a variable while evaluating; numbers are float64
+type exprLookup func(name string) (interface{}, error)
+
+type exprNode interface {
+	exprType() exprType
+}
+
+type exprLiteral struct {
+	typ   exprType
+	value interface{}
+}
+
+type exprVar struct {
+	name string
+	typ  exprType
+}
+
+type exprUnary struct {
+	op string
+	x  exprNode
+}
+
+type exprBinary struct {
+	op   string
+	x, y exprNode
+	typ  exprType
+}
+
+func (n *exprLiteral) exprType() exprType { return n.typ }
+func (n *exprVar) exprType() exprType     { return n.typ }
+func (n *exprUnary) exprType() exprType   { return n.x.exprType() }
+func (n *exprBinary) exprType() exprType  { return n.typ }
+
+// compiledExpr is a parsed, type-checked boolean expression
+type compiledExpr struct {
+	source string
+	root   exprNode
+	vars   []string // Variables in order of first use
+}
+
+// compileExpr parses source against the known variable types
+func compileExpr(source string, vars map[string]exprType) (*compiledExpr, error) {
+	tokens, err := lexExpr(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &exprParser{tokens: tokens, vars: vars, used: make(map[string]bool)}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+	}
+	if root.exprType() != exprBool {
+		return nil, fmt.Errorf("expression is a number, expected a condition")
+	}
+
+	return &compiledExpr{source: source, root: root, vars: p.order}, nil
+}
+
+// eval evaluates the expression. && and || short-circuit, so variables on
+// the skipped side are never looked up.
+func (e *compiledExpr) eval(lookup exprLookup) (bool, error) {
+	value, err := evalExpr(e.root, lookup)
+	if err != nil {
+		return false, err
+	}
+	return value.(bool), nil
+}
+
+func evalExpr(node exprNode, lookup exprLookup) (interface{}, error) {
+	switch n := node.(type) {
+	case *exprLiteral:
+		return n.value, nil
+
+	case *exprVar:
+		return lookup(n.name)
+
+	case *exprUnary:
+		x, err := evalExpr(n.x, lookup)
+		if err != nil {
+			return nil, err
+		}
+		if n.op == "!" {
+			return !x.(bool), nil
+		}
+		return -x.(float64), nil
+
+	case *exprBinary:
+		x, err := evalExpr(n.x, lookup)
+		if err != nil {
+			return nil, err
+		}
+		switch n.op {
+		case "&&":
+			if !x.(bool) {
+				return false, nil
+			}
+			return evalExpr(n.y, lookup)
+		case "||":
+			if x.(bool) {
+				return true, nil
+			}
+			return evalExpr(n.y, lookup)
+		}
+
+		y, err := evalExpr(n.y, lookup)
+		if err != nil {
+			return nil, err
+		}
+		switch n.op {
+		case "==":
+			return x == y, nil
+		case "!=":
+			return x != y, nil
+		}
+
+		a, b := x.(float64), y.(float64)
+		switch n.op {
+		case "<":
+			return a < b, nil
+		case "<=":
+			return a <= b, nil
+		case ">":
+			return a > b, nil
+		case ">=":
+			return a >= b, nil
+		case "+":
+			return a + b, nil
+		case "-":
+			return a - b, nil
+		case "*":
+			return a * b, nil
+		case "/":
+			if b == 0 {
+				return nil, fmt.Errorf("division by zero")
+			}
+			return a / b, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid expression node %T", node)
+}
+
+// Parser
+
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+	vars   map[string]exprType
+	used   map[string]bool
+	order  []string
+}
+
+func (p *exprParser) peek() exprToken {
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) next() exprToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// accept consumes the next token if it is one of ops
+func (p *exprParser) accept(ops ...string) (string, bool) {
+	tok := p.peek()
+	if tok.kind != tokOp {
+		return "", false
+	}
+	for _, op := range ops {
+		if tok.text == op {
+			p.pos++
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *exprParser) parseOr() (exprNode, error) {
+	return p.parseLogical("||", p.parseAnd)
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+	return p.parseLogical("&&", p.parseNot)
+}
+
+func (p *exprParser) parseLogical(op string, operand func() (exprNode, error)) (exprNode, error) {
+	x, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept(op); !ok {
+			return x, nil
+		}
+		y, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		if err := expectTypes(op, exprBool, x, y); err != nil {
+			return nil, err
+		}
+		x = &exprBinary{op: op, x: x, y: y, typ: exprBool}
+	}
+}
+
+func (p *exprParser) parseNot() (exprNode, error) {
+	if _, ok := p.accept("!"); ok {
+		x, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		if err := expectTypes("!", exprBool, x); err != nil {
+			return nil, err
+		}
+		return &exprUnary{op: "!", x: x}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *exprParser) parseComparison() (exprNode, error) {
+	x, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	op, ok := p.accept("==", "!=", "<", "<=", ">", ">=")
+	if !ok {
+		return x, nil
+	}
+	y, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+
+	if op == "==" || op == "!=" {
+		if x.exprType() != y.exprType() {
+			return nil, fmt.Errorf("cannot compare %s %s %s", x.exprType(), op, y.exprType())
+		}
+	} else if err := expectTypes(op, exprNumber, x, y); err != nil {
+		return nil, err
+	}
+	return &exprBinary{op: op, x: x, y: y, typ: exprBool}, nil
+}
+
+func (p *exprParser) parseSum() (exprNode, error) {
+	return p.parseArithmetic([]string{"+", "-"}, p.parseProduct)
+}
+
+func (p *exprParser) parseProduct() (exprNode, error) {
+	return p.parseArithmetic([]string{"*", "/"}, p.parseUnary)
+}
+
+func (p *exprParser) parseArithmetic(ops []string, operand func() (exprNode, error)) (exprNode, error) {
+	x, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept(ops...)
+		if !ok {
+			return x, nil
+		}
+		y, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		if err := expectTypes(op, exprNumber, x, y); err != nil {
+			return nil, err
+		}
+		x = &exprBinary{op: op, x: x, y: y, typ: exprNumber}
+	}
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if _, ok := p.accept("-"); ok {
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if err := expectTypes("-", exprNumber, x); err != nil {
+			return nil, err
+		}
+		return &exprUnary{op: "-", x: x}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokNumber:
+		value, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", tok.text, tok.pos)
+		}
+		return &exprLiteral{typ: exprNumber, value: value}, nil
+
+	case tokIdent:
+		switch tok.text {
+		case "true", "false":
+			return &exprLiteral{typ: exprBool, value: tok.text == "true"}, nil
+		}
+		typ, ok := p.vars[tok.text]
+		if !ok {
+			return nil, fmt.Errorf("unknown variable %q", tok.text)
+		}
+		if !p.used[tok.text] {
+			p.used[tok.text] = true
+			p.order = append(p.order, tok.text)
+		}
+		return &exprVar{name: tok.text, typ: typ}, nil
+
+	case tokOp:
+		if tok.text == "(" {
+			x, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := p.accept(")"); !ok {
+				return nil, fmt.Errorf("missing ) at position %d", p.peek().pos)
+			}
+			return x, nil
+		}
+	case tokEOF:
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+}
+
+func expectTypes(op string, want exprType, operands ...exprNode) error {
+	for _, operand := range operands {
+		if operand.exprType() != want {
+			return fmt.Errorf("%s needs %s operands, got %s", op, want, operand.exprType())
+		}
+	}
+	return nil
+}
+
+// Lexer
+
+type exprTokenKind int
+
+const (
+	tokEOF exprTokenKind = iota
+	tokNumber
+	tokIdent
+	tokOp
+)
+
+type exprToken struct {
+	kind exprTokenKind
+	text string
+	pos  int
+}
+
+// Word forms of the logical operators, handy in YAML where a leading ! is a tag
+var exprKeywordOps = map[string]string{
+	"and": "&&",
+	"or":  "||",
+	"not": "!",
+}
+
+var exprOps = []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "+", "-", "*", "/", "(", ")"}
+
+func lexExpr(source string) ([]exprToken, error) {
+	var tokens []exprToken
+	i := 0
+	for i < len(source) {
+		c := source[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+
+		case isDigit(c) || (c == '.' && i+1 < len(source) && isDigit(source[i+1])):
+			start := i
+			for i < len(source) && (isDigit(source[i]) || source[i] == '.' || source[i] == '_') {
+				i++
+			}
+			text := strings.ReplaceAll(source[start:i], "_", "")
+			tokens = append(tokens, exprToken{kind: tokNumber, text: text, pos: start})
+
+		case isIdentStart(c):
+			start := i
+			for i < len(source) && (isIdentStart(source[i]) || isDigit(source[i])) {
+				i++
+			}
+			word := source[start:i]
+			if op, ok := exprKeywordOps[word]; ok {
+				tokens = append(tokens, exprToken{kind: tokOp, text: op, pos: start})
+			} else {
+				tokens = append(tokens, exprToken{kind: tokIdent, text: word, pos: start})
+			}
+
+		default:
+			op := ""
+			for _, candidate := range exprOps {
+				if strings.HasPrefix(source[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected %q at position %d", string(c), i)
+			}
+			tokens = append(tokens, exprToken{kind: tokOp, text: op, pos: i})
+			i += len(op)
+		}
+	}
+	return append(tokens, exprToken{kind: tokEOF, pos: len(source)}), nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,193 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+var testExprVars = map[string]exprType{
+	"holders":        exprNumber,
+	"top10_pct":      exprNumber,
+	"mint_authority": exprBool,
+}
+
+func testLookup(values map[string]interface{}, seen map[string]bool) exprLookup {
+	return func(name string) (interface{}, error) {
+		if seen != nil {
+			seen[name] = true
+		}
+		value, ok := values[name]
+		if !ok {
+			return nil, fmt.Errorf("no value for %s", name)
+		}
+		return value, nil
+	}
+}
+
+func TestExprEval(t *testing.T) {
+	values := map[string]interface{}{"holders": 50.0, "top10_pct": 40.0, "mint_authority": false}
+
+	tests := []struct {
+		source string
+		want   bool
+	}{
+		// Precedence and associativity
+		{"1 + 2 * 3 == 7", true},
+		{"(1 + 2) * 3 == 9", true},
+		{"10 - 4 - 3 == 3", true},
+		{"12 / 3 / 2 == 2", true},
+		{"-2 * 3 == -6", true},
+		{"- -2 == 2", true},
+		{"true || false && false", true},
+		{"(true || false) && false", false},
+		{"!false && false", false},
+		{"!(false && false)", true},
+		{"!holders > 100", true},
+
+		// Keywords
+		{"holders >= 20 and top10_pct < 60", true},
+		{"holders < 20 or top10_pct < 60", true},
+		{"not mint_authority", true},
+		{"not mint_authority and not holders < 20", true},
+		{"holders > 20 and not (top10_pct < 60 or mint_authority)", false},
+
+		// Numbers
+		{"1_000_000 == 1000000", true},
+		{"0.5 + .5 == 1", true},
+		{"holders * 2 == 100", true},
+
+		// Comparisons
+		{"holders == 50", true},
+		{"holders != 50", false},
+		{"mint_authority == false", true},
+		{"mint_authority != true", true},
+		{"holders <= 50 && holders >= 50 && !(holders < 50) && !(holders > 50)", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			expr, err := compileExpr(tt.source, testExprVars)
+			if err != nil {
+				t.Fatalf("compileExpr: %v", err)
+			}
+			got, err := expr.eval(testLookup(values, nil))
+			if err != nil {
+				t.Fatalf("eval: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("eval = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExprCompileErrors(t *testing.T) {
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{"holders && true", "&& needs bool operands"},
+		{"true and holders", "&& needs bool operands"},
+		{"holders || mint_authority", "|| needs bool operands"},
+		{"!holders", "! needs bool operands"},
+		{"not holders", "! needs bool operands"},
+		{"-mint_authority", "- needs number operands"},
+		{"mint_authority > 1", "> needs number operands"},
+		{"holders + true > 1", "+ needs number operands"},
+		{"holders == true", "cannot compare number == bool"},
+		{"holders + 1", "expression is a number"},
+		{"holderz > 5", `unknown variable "holderz"`},
+		{"(holders > 5", "missing )"},
+		{"holders > 5)", `unexpected ")"`},
+		{"((holders > 5)", "missing )"},
+		{"holders >", "unexpected end of expression"},
+		{"", "unexpected end of expression"},
+		{"holders > 5 5", `unexpected "5"`},
+		{"holders # 5", `unexpected "#"`},
+		{"1.2.3 > 0", `invalid number "1.2.3"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			_, err := compileExpr(tt.source, testExprVars)
+			if err == nil {
+				t.Fatalf("compileExpr succeeded, want error containing %q", tt.want)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("compileExpr error = %q, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestExprShortCircuit(t *testing.T) {
+	values := map[string]interface{}{"holders": 50.0, "mint_authority": true}
+
+	tests := []struct {
+		source  string
+		want    bool
+		skipped string
+	}{
+		{"holders < 20 && top10_pct < 60", false, "top10_pct"},
+		{"holders > 20 || top10_pct < 60", true, "top10_pct"},
+		{"holders < 20 and top10_pct / 0 > 1", false, "top10_pct"},
+		{"not mint_authority and top10_pct < 60", false, "top10_pct"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			expr, err := compileExpr(tt.source, testExprVars)
+			if err != nil {
+				t.Fatalf("compileExpr: %v", err)
+			}
+			seen := make(map[string]bool)
+			got, err := expr.eval(testLookup(values, seen))
+			if err != nil {
+				t.Fatalf("eval: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("eval = %v, want %v", got, tt.want)
+			}
+			if seen[tt.skipped] {
+				t.Errorf("%s was looked up on the skipped side", tt.skipped)
+			}
+		})
+	}
+}
+
+func TestExprEvalErrors(t *testing.T) {
+	values := map[string]interface{}{"holders": 0.0}
+
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{"10 / holders > 1", "division by zero"},
+		{"1 / (holders - holders) > 0 || true", "division by zero"},
+		{"top10_pct < 60", "no value for top10_pct"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			expr, err := compileExpr(tt.source, testExprVars)
+			if err != nil {
+				t.Fatalf("compileExpr: %v", err)
+			}
+			_, err = expr.eval(testLookup(values, nil))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("eval error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestExprVariableOrder(t *testing.T) {
+	expr, err := compileExpr("top10_pct < 60 && holders > 10 && top10_pct > 1", testExprVars)
+	if err != nil {
+		t.Fatalf("compileExpr: %v", err)
+	}
+	if got := strings.Join(expr.vars, ","); got != "top10_pct,holders" {
+		t.Errorf("vars = %s, want top10_pct,holders", got)
+	}
+}
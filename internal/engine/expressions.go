@@ -0,0 +1,248 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/speier/tokenscout/internal/models"
+	"github.com/speier/tokenscout/internal/solana"
+)
+
+// exprVariable is a token fact that rules.expressions can read
+type exprVariable struct {
+	typ   exprType
+	input FactKind
+	get   func(ctx context.Context, facts *TokenFacts) (interface{}, error)
+}
+
+// exprVariables are the names usable in rules.expressions
+var exprVariables = map[string]exprVariable{
+	// Holders
+	"holders":        holdersVariable(func(dist *solana.HolderDistribution) float64 { return float64(len(dist.Holders)) }),
+	"top_holder_pct": holdersVariable(func(dist *solana.HolderDistribution) float64 { return dist.TopHolderPct() }),
+	"top10_pct":      holdersVariable(func(dist *solana.HolderDistribution) float64 { return dist.Top10Pct() }),
+	"cluster_top_pct": {exprNumber, FactHolderClusters, func(ctx context.Context, facts *TokenFacts) (interface{}, error) {
+		return clusterTopPct(ctx, facts)
+	}},
+
+	// Mint age
+	"age_sec": {exprNumber, FactMintAge, func(ctx context.Context, facts *TokenFacts) (interface{}, error) {
+		age, err := facts.MintAge(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return age.Seconds(), nil
+	}},
+
+	// Pool
+	"liquidity_usd": {exprNumber, FactPool, func(ctx context.Context, facts *TokenFacts) (interface{}, error) {
+		reserves, err := facts.Pool(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return reserves.LiquidityUSD, nil
+	}},
+	"lp_burned_pct": {exprNumber, FactPool, func(ctx context.Context, facts *TokenFacts) (interface{}, error) {
+		return checkLPBurned(ctx, facts)
+	}},
+
+	// Mint account
+	"supply": tokenInfoVariable(exprNumber, func(info *solana.TokenInfo) interface{} {
+		return float64(info.Supply) / math.Pow10(int(info.Decimals))
+	}),
+	"decimals":         tokenInfoVariable(exprNumber, func(info *solana.TokenInfo) interface{} { return float64(info.Decimals) }),
+	"transfer_fee_pct": tokenInfoVariable(exprNumber, func(info *solana.TokenInfo) interface{} { return info.Extensions.TransferFeePct() }),
+	"mint_authority":   tokenInfoVariable(exprBool, func(info *solana.TokenInfo) interface{} { return info.HasMintAuthority }),
+	"freeze_authority": tokenInfoVariable(exprBool, func(info *solana.TokenInfo) interface{} { return info.HasFreezeAuthority }),
+	"mutable_metadata": tokenInfoVariable(exprBool, func(info *solana.TokenInfo) interface{} {
+		return info.Metadata != nil && info.Metadata.IsMutable
+	}),
+	"update_authority": tokenInfoVariable(exprBool, func(info *solana.TokenInfo) interface{} {
+		return info.Metadata != nil && info.Metadata.HasLiveUpdateAuthority()
+	}),
+	"token2022":          tokenInfoVariable(exprBool, func(info *solana.TokenInfo) interface{} { return info.IsToken2022() }),
+	"transfer_hook":      tokenInfoVariable(exprBool, func(info *solana.TokenInfo) interface{} { return info.Extensions.TransferHook != nil }),
+	"permanent_delegate": tokenInfoVariable(exprBool, func(info *solana.TokenInfo) interface{} { return info.Extensions.PermanentDelegate != nil }),
+}
+
+func holdersVariable(pick func(dist *solana.HolderDistribution) float64) exprVariable {
+	return exprVariable{exprNumber, FactHolders, func(ctx context.Context, facts *TokenFacts) (interface{}, error) {
+		holders, err := facts.Holders(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return pick(holders), nil
+	}}
+}
+
+// clusterTopPct is the top holder's share with sybil clusters counted as one
+// holder, the plain top holder's share when clustering is off
+func clusterTopPct(ctx context.Context, facts *TokenFacts) (float64, error) {
+	holders, err := facts.Holders(ctx)
+	if err != nil {
+		return 0, err
+	}
+	clusters, err := facts.HolderClusters(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(clusters) == 0 {
+		return holders.TopHolderPct(), nil
+	}
+	return math.Max(holders.TopHolderPct(), holders.Pct(clusters[0].Amount)), nil
+}
+
+func tokenInfoVariable(typ exprType, pick func(info *solana.TokenInfo) interface{}) exprVariable {
+	return exprVariable{typ, FactTokenInfo, func(ctx context.Context, facts *TokenFacts) (interface{}, error) {
+		info, err := facts.TokenInfo(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return pick(info), nil
+	}}
+}
+
+// expressionRule is a rule compiled from rules.expressions
+type expressionRule struct {
+	name     string
+	severity RuleSeverity
+	expr     *compiledExpr
+	inputs   []FactKind
+}
+
+func (r *expressionRule) Name() string           { return r.name }
+func (r *expressionRule) Inputs() []FactKind     { return r.inputs }
+func (r *expressionRule) Severity() RuleSeverity { return r.severity }
+
+// Check evaluates the expression; a failure lists the values it saw
+func (r *expressionRule) Check(ctx context.Context, facts *TokenFacts) RuleVerdict {
+	values := make(map[string]interface{})
+	var seen []string
+
+	pass, err := r.expr.eval(func(name string) (interface{}, error) {
+		if value, ok := values[name]; ok {
+			return value, nil
+		}
+		value, err := exprVariables[name].get(ctx, facts)
+		if err != nil {
+			return nil, fmt.Errorf("%s unavailable", name)
+		}
+		values[name] = value
+		seen = append(seen, name)
+		return value, nil
+	})
+	if err != nil {
+		return RuleFail("%s: %s", r.name, err.Error())
+	}
+	if pass {
+		return RulePass()
+	}
+
+	shown := make([]string, 0, len(seen))
+	for _, name := range seen {
+		shown = append(shown, name+"="+formatExprValue(values[name]))
+	}
+	return RuleFail("%s: %s (%s)", r.name, r.expr.source, strings.Join(shown, ", "))
+}
+
+func formatExprValue(value interface{}) string {
+	if number, ok := value.(float64); ok {
+		return strconv.FormatFloat(math.Round(number*100)/100, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+// compileExpressionRule type-checks one configured expression. index is its
+// position in rules.expressions, used to name unnamed entries.
+func compileExpressionRule(index int, expression models.RuleExpression) (*expressionRule, error) {
+	name := expressionName(index, expression)
+
+	severity := RuleSeverity(expression.Severity)
+	switch severity {
+	case "":
+		severity = SeverityCritical
+	case SeverityCritical, SeverityWatch, SeverityWarn:
+	default:
+		return nil, fmt.Errorf("rule %s: unknown severity %q", name, expression.Severity)
+	}
+
+	types := make(map[string]exprType, len(exprVariables))
+	for varName, variable := range exprVariables {
+		types[varName] = variable.typ
+	}
+	compiled, err := compileExpr(expression.Expr, types)
+	if err != nil {
+		return nil, fmt.Errorf("rule %s: %w", name, err)
+	}
+
+	var inputs []FactKind
+	seen := make(map[FactKind]bool)
+	for _, varName := range compiled.vars {
+		input := exprVariables[varName].input
+		if !seen[input] {
+			seen[input] = true
+			inputs = append(inputs, input)
+		}
+	}
+
+	return &expressionRule{
+		name:     name,
+		severity: severity,
+		expr:     compiled,
+		inputs:   inputs,
+	}, nil
+}
+
+// compileExpressionRules compiles rules.expressions in order. An expression
+// that doesn't compile becomes a critical rule that always fails, so a
+// broken filter never lets tokens through.
+func compileExpressionRules(expressions []models.RuleExpression) []Rule {
+	rules := make([]Rule, 0, len(expressions))
+	for i, expression := range expressions {
+		rule, err := compileExpressionRule(i, expression)
+		if err != nil {
+			reason := "invalid expression: " + err.Error()
+			rules = append(rules, NewRule(expressionName(i, expression), SeverityCritical, nil, func(ctx context.Context, facts *TokenFacts) RuleVerdict {
+				return RuleFail("%s", reason)
+			}))
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func expressionName(index int, expression models.RuleExpression) string {
+	if expression.Name != "" {
+		return expression.Name
+	}
+	return fmt.Sprintf("expression_%d", index+1)
+}
+
+// validateExpressions compiles rules.expressions and reports the first
+// error, including names that clash with built-in rules or each other
+func validateExpressions(expressions []models.RuleExpression) error {
+	registered := make(map[string]bool)
+	for _, name := range RegisteredRules() {
+		registered[name] = true
+	}
+
+	names := make(map[string]bool)
+	for i, expression := range expressions {
+		if _, err := compileExpressionRule(i, expression); err != nil {
+			return err
+		}
+		name := expressionName(i, expression)
+		if registered[name] {
+			return fmt.Errorf("rule %s: name is taken by a built-in rule", name)
+		}
+		if names[name] {
+			return fmt.Errorf("rule %s: duplicate name", name)
+		}
+		names[name] = true
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package engine
+
+import (
+	"sync"
+	"time"
+)
+
+// factCacheTTL covers a token's stay on the watch list and the processor's
+// dedupe window, so re-checks reuse what the first evaluation fetched
+const factCacheTTL = 5 * time.Minute
+
+// stableFacts change rarely enough to be cached across evaluations: the mint
+// account (authorities, decimals, extensions, metadata) and creation time.
+// Holders and pools are what watch-list re-checks wait on, so they are
+// always fetched fresh.
+var stableFacts = map[FactKind]bool{
+	FactTokenInfo: true,
+	FactMintAge:   true,
+}
+
+// factCache keeps stable facts per mint for a TTL. A nil cache stores nothing.
+type factCache struct {
+	ttl time.Duration
+
+	mu        sync.Mutex
+	entries   map[factCacheKey]cachedFact
+	lastSweep time.Time
+}
+
+type factCacheKey struct {
+	mint string
+	kind FactKind
+}
+
+type cachedFact struct {
+	value    interface{}
+	storedAt time.Time
+}
+
+func newFactCache(ttl time.Duration) *factCache {
+	return &factCache{
+		ttl:       ttl,
+		entries:   make(map[factCacheKey]cachedFact),
+		lastSweep: time.Now(),
+	}
+}
+
+// get returns a cached fact younger than the TTL
+func (c *factCache) get(mint string, kind FactKind) (interface{}, bool) {
+	if c == nil || !stableFacts[kind] {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[factCacheKey{mint, kind}]
+	if !ok || time.Since(entry.storedAt) > c.ttl {
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// put stores a stable fact; other kinds are ignored. Expired entries are
+// dropped at most once per TTL.
+func (c *factCache) put(mint string, kind FactKind, value interface{}) {
+	if c == nil || !stableFacts[kind] {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	c.entries[factCacheKey{mint, kind}] = cachedFact{value: value, storedAt: now}
+
+	if now.Sub(c.lastSweep) < c.ttl {
+		return
+	}
+	for key, entry := range c.entries {
+		if now.Sub(entry.storedAt) > c.ttl {
+			delete(c.entries, key)
+		}
+	}
+	c.lastSweep = now
+}
@@ -0,0 +1,145 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/speier/tokenscout/internal/models"
+)
+
+func TestFactCache(t *testing.T) {
+	cache := newFactCache(time.Minute)
+
+	cache.put("mint", FactTokenInfo, "info")
+	if value, ok := cache.get("mint", FactTokenInfo); !ok || value != "info" {
+		t.Errorf("get(token_info) = %v, %v, want the stored value", value, ok)
+	}
+	if _, ok := cache.get("other", FactTokenInfo); ok {
+		t.Error("get found a fact for a mint never stored")
+	}
+
+	// Holders and pools are always fetched fresh
+	cache.put("mint", FactHolders, "holders")
+	if _, ok := cache.get("mint", FactHolders); ok {
+		t.Error("holders were cached")
+	}
+
+	// Expired entries are misses, and dropped by the next sweep
+	cache.entries[factCacheKey{"old", FactMintAge}] = cachedFact{value: "age", storedAt: time.Now().Add(-2 * time.Minute)}
+	if _, ok := cache.get("old", FactMintAge); ok {
+		t.Error("get returned an expired fact")
+	}
+	cache.lastSweep = time.Now().Add(-2 * time.Minute)
+	cache.put("mint", FactMintAge, "age")
+	if _, ok := cache.entries[factCacheKey{"old", FactMintAge}]; ok {
+		t.Error("sweep kept an expired fact")
+	}
+	if _, ok := cache.get("mint", FactTokenInfo); !ok {
+		t.Error("sweep dropped a fresh fact")
+	}
+
+	// A nil cache stores nothing
+	var none *factCache
+	none.put("mint", FactTokenInfo, "info")
+	if _, ok := none.get("mint", FactTokenInfo); ok {
+		t.Error("nil cache returned a fact")
+	}
+}
+
+func TestTokenFactsFetch(t *testing.T) {
+	ctx := context.Background()
+	cache := newFactCache(time.Minute)
+	event := &models.Event{Mint: "mint"}
+
+	var loads int32
+	load := func(ctx context.Context) (interface{}, error) {
+		atomic.AddInt32(&loads, 1)
+		time.Sleep(10 * time.Millisecond)
+		return "value", nil
+	}
+
+	// Concurrent readers share one fetch
+	facts := newTokenFacts(event, &models.Config{}, nil, nil, cache, nil)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if value, err := facts.fetch(ctx, FactTokenInfo, load); err != nil || value != "value" {
+				t.Errorf("fetch = %v, %v", value, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if loads != 1 {
+		t.Errorf("loaded %d times, want once", loads)
+	}
+
+	// A later evaluation reuses stable facts from the cache
+	facts = newTokenFacts(event, &models.Config{}, nil, nil, cache, nil)
+	facts.fetch(ctx, FactTokenInfo, load)
+	if loads != 1 {
+		t.Errorf("cached fact loaded again (%d loads)", loads)
+	}
+
+	// Failed fetches aren't cached
+	failing := func(ctx context.Context) (interface{}, error) {
+		atomic.AddInt32(&loads, 1)
+		return nil, errors.New("rpc down")
+	}
+	facts.fetch(ctx, FactMintAge, failing)
+	facts = newTokenFacts(event, &models.Config{}, nil, nil, cache, nil)
+	facts.fetch(ctx, FactMintAge, failing)
+	if loads != 3 {
+		t.Errorf("failed fetch was cached (%d loads, want 3)", loads)
+	}
+}
+
+func TestTierInputs(t *testing.T) {
+	noop := func(ctx context.Context, facts *TokenFacts) RuleVerdict { return RulePass() }
+	rules := []Rule{
+		NewRule("blacklist", SeverityCritical, nil, noop),
+		NewRule("authorities", SeverityCritical, []FactKind{FactTokenInfo}, noop),
+		NewRule("mint_age", SeverityCritical, []FactKind{FactMintAge}, noop),
+		NewRule("min_holders", SeverityWatch, []FactKind{FactHolders}, noop),
+		NewRule("min_liquidity", SeverityWatch, []FactKind{FactPool}, noop),
+		NewRule("holder_clusters", SeverityCritical, []FactKind{FactHolders, FactHolderClusters}, noop),
+	}
+
+	tests := []struct {
+		name        string
+		next        int // Index of the rule about to run
+		fetchedTier int
+		fullReport  bool
+		wantKinds   string
+		wantTier    int
+	}{
+		{"rule without inputs", 0, -1, false, "", -1},
+		{"first cheap rule starts every cheap fact", 1, -1, false, "mint_age,token_info", 0},
+		{"cheap tier already started", 2, 0, false, "", 0},
+		{"first heavy rule starts the heavy tier", 3, 0, false, "holders,pool", 1},
+		{"heavy tier already started", 4, 1, false, "", 1},
+		{"clusters start last", 5, 1, false, "holder_clusters,holders", 2},
+		{"full report starts everything", 0, -1, true, "holder_clusters,holders,mint_age,pool,token_info", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kinds, tier := tierInputs(rules[tt.next:], tt.fetchedTier, tt.fullReport)
+			names := make([]string, len(kinds))
+			for i, kind := range kinds {
+				names[i] = string(kind)
+			}
+			sort.Strings(names)
+			if got := strings.Join(names, ","); got != tt.wantKinds || tier != tt.wantTier {
+				t.Errorf("tierInputs = [%s] tier %d, want [%s] tier %d", got, tier, tt.wantKinds, tt.wantTier)
+			}
+		})
+	}
+}
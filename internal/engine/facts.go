@@ -0,0 +1,254 @@
+package engine
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/speier/tokenscout/internal/models"
+	"github.com/speier/tokenscout/internal/repository"
+	"github.com/speier/tokenscout/internal/solana"
+)
+
+// TokenFacts is what the rules know about the token being evaluated. Each
+// fact is fetched once and shared by every rule that reads it; facts can be
+// fetched concurrently ahead of the rules that need them.
+type TokenFacts struct {
+	Event  *models.Event
+	Config *models.Config
+
+	repo      repository.Repository
+	rpcClient *rpc.Client
+	cache     *factCache
+	sizer     PositionSizer
+
+	mu      sync.Mutex
+	fetches map[FactKind]*factFetch
+}
+
+// factFetch is one fact's fetch; done closes when value and err are set
+type factFetch struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+func newTokenFacts(event *models.Event, config *models.Config, repo repository.Repository, rpcClient *rpc.Client, cache *factCache, sizer PositionSizer) *TokenFacts {
+	return &TokenFacts{
+		Event:     event,
+		Config:    config,
+		repo:      repo,
+		rpcClient: rpcClient,
+		cache:     cache,
+		sizer:     sizer,
+		fetches:   make(map[FactKind]*factFetch),
+	}
+}
+
+// Mint is the address of the token being evaluated
+func (f *TokenFacts) Mint() string {
+	return f.Event.Mint
+}
+
+// Repo gives rules access to stored data (blacklist, history)
+func (f *TokenFacts) Repo() repository.Repository {
+	return f.repo
+}
+
+// RPC gives custom rules direct chain access for data TokenFacts doesn't cover
+func (f *TokenFacts) RPC() *rpc.Client {
+	return f.rpcClient
+}
+
+// TokenInfo is the mint account with authorities, extensions and metadata
+func (f *TokenFacts) TokenInfo(ctx context.Context) (*solana.TokenInfo, error) {
+	value, err := f.fetch(ctx, FactTokenInfo, func(ctx context.Context) (interface{}, error) {
+		return solana.GetTokenInfo(ctx, f.rpcClient, f.Mint())
+	})
+	info, _ := value.(*solana.TokenInfo)
+	return info, err
+}
+
+// Holders is the mint's supply by wallet, without the pool's vaults, the LP
+// authority or burned tokens
+func (f *TokenFacts) Holders(ctx context.Context) (*solana.HolderDistribution, error) {
+	value, err := f.fetch(ctx, FactHolders, func(ctx context.Context) (interface{}, error) {
+		return f.fetchHolders(ctx)
+	})
+	holders, _ := value.(*solana.HolderDistribution)
+	return holders, err
+}
+
+// HolderClusters are groups of top holders funded by the same wallet around
+// launch, largest first. Empty when rules.holder_cluster_window_slots is off.
+func (f *TokenFacts) HolderClusters(ctx context.Context) ([]solana.HolderCluster, error) {
+	value, err := f.fetch(ctx, FactHolderClusters, func(ctx context.Context) (interface{}, error) {
+		return f.fetchHolderClusters(ctx)
+	})
+	clusters, _ := value.([]solana.HolderCluster)
+	return clusters, err
+}
+
+// MintAge is how long ago the mint's first transaction landed
+func (f *TokenFacts) MintAge(ctx context.Context) (time.Duration, error) {
+	value, err := f.fetch(ctx, FactMintAge, func(ctx context.Context) (interface{}, error) {
+		return solana.GetTokenAge(ctx, f.rpcClient, f.Mint())
+	})
+	if err != nil {
+		return 0, err
+	}
+	createdAt, _ := value.(time.Time)
+	return time.Since(createdAt), nil
+}
+
+// Pool is the token's deepest priced pool, using the event's pool address
+// when the listener captured one
+func (f *TokenFacts) Pool(ctx context.Context) (*solana.PoolReserves, error) {
+	value, err := f.fetch(ctx, FactPool, func(ctx context.Context) (interface{}, error) {
+		return f.fetchPool(ctx)
+	})
+	reserves, _ := value.(*solana.PoolReserves)
+	return reserves, err
+}
+
+// Deployer is the wallet that created the mint and the wallet that funded it.
+// It is looked up once per mint and recorded, so trade outcomes can be traced
+// back to it.
+func (f *TokenFacts) Deployer(ctx context.Context) (*models.MintDeployer, error) {
+	value, err := f.fetch(ctx, FactDeployer, func(ctx context.Context) (interface{}, error) {
+		return recordDeployer(ctx, f.repo, f.rpcClient, f.Mint())
+	})
+	deployer, _ := value.(*models.MintDeployer)
+	return deployer, err
+}
+
+// TradeSize is the SOL a buy of this token would spend. The conviction score
+// isn't known until the rules have run, so score sizing is asked at full
+// conviction, the most it spends. Without a sizer it is max_spend_per_trade.
+func (f *TokenFacts) TradeSize(ctx context.Context) (float64, error) {
+	value, err := f.fetch(ctx, FactTradeSize, func(ctx context.Context) (interface{}, error) {
+		if f.sizer == nil {
+			return f.Config.Trading.MaxSpendPerTrade, nil
+		}
+		size, err := f.sizer.Size(ctx, f.Mint(), 1)
+		if err != nil {
+			return nil, err
+		}
+		return size.SOL, nil
+	})
+	solAmount, _ := value.(float64)
+	return solAmount, err
+}
+
+// fetch runs load once per fact. Callers that arrive while it is running wait
+// for its result. Stable facts come from the cache when it has them.
+func (f *TokenFacts) fetch(ctx context.Context, kind FactKind, load func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	f.mu.Lock()
+	if existing, ok := f.fetches[kind]; ok {
+		f.mu.Unlock()
+		select {
+		case <-existing.done:
+			return existing.value, existing.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	fetch := &factFetch{done: make(chan struct{})}
+	f.fetches[kind] = fetch
+	f.mu.Unlock()
+
+	if value, ok := f.cache.get(f.Mint(), kind); ok {
+		fetch.value = value
+	} else {
+		fetch.value, fetch.err = load(ctx)
+		if fetch.err == nil {
+			f.cache.put(f.Mint(), kind, fetch.value)
+		}
+	}
+	close(fetch.done)
+	return fetch.value, fetch.err
+}
+
+// prefetch starts fetching facts in the background, so rules that read them
+// don't wait for each fetch in turn. Facts already fetched or in flight are
+// skipped.
+func (f *TokenFacts) prefetch(ctx context.Context, kinds []FactKind) {
+	for _, kind := range kinds {
+		f.mu.Lock()
+		_, started := f.fetches[kind]
+		f.mu.Unlock()
+		if started {
+			continue
+		}
+
+		switch kind {
+		case FactTokenInfo:
+			go f.TokenInfo(ctx)
+		case FactHolders:
+			go f.Holders(ctx)
+		case FactHolderClusters:
+			go f.HolderClusters(ctx)
+		case FactMintAge:
+			go f.MintAge(ctx)
+		case FactPool:
+			go f.Pool(ctx)
+		case FactDeployer:
+			go f.Deployer(ctx)
+		case FactTradeSize:
+			go f.TradeSize(ctx)
+		}
+	}
+}
+
+func (f *TokenFacts) fetchHolders(ctx context.Context) (*solana.HolderDistribution, error) {
+	accounts, err := solana.GetTokenHolders(ctx, f.rpcClient, f.Mint())
+	if err != nil {
+		return nil, err
+	}
+	return solana.GetHolderDistribution(ctx, f.rpcClient, accounts, f.Event.LPAddress), nil
+}
+
+func (f *TokenFacts) fetchHolderClusters(ctx context.Context) ([]solana.HolderCluster, error) {
+	windowSlots := f.Config.Rules.HolderClusterWindowSlots
+	if windowSlots <= 0 {
+		return nil, nil
+	}
+
+	dist, err := f.Holders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	launchSlot, err := solana.GetLaunchSlot(ctx, f.rpcClient, f.Mint())
+	if err != nil {
+		return nil, err
+	}
+	return solana.FindHolderClusters(ctx, f.rpcClient, dist, launchSlot, uint64(windowSlots)), nil
+}
+
+func (f *TokenFacts) fetchPool(ctx context.Context) (*solana.PoolReserves, error) {
+	solPrice, err := solana.GetSOLPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return solana.GetPoolLiquidity(ctx, f.rpcClient, f.Mint(), f.Event.LPAddress, solPrice)
+}
+
+// symbol is the token's symbol for logs, "" until token info has loaded
+func (f *TokenFacts) symbol() string {
+	f.mu.Lock()
+	fetch, ok := f.fetches[FactTokenInfo]
+	f.mu.Unlock()
+	if !ok {
+		return ""
+	}
+
+	select {
+	case <-fetch.done:
+		if info, _ := fetch.value.(*solana.TokenInfo); info != nil {
+			return info.Symbol
+		}
+	default:
+	}
+	return ""
+}
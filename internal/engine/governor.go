@@ -0,0 +1,193 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/speier/tokenscout/internal/logger"
+	"github.com/speier/tokenscout/internal/models"
+	"github.com/speier/tokenscout/internal/repository"
+)
+
+// RiskState is the portfolio-level view the risk governor last computed
+type RiskState struct {
+	EntriesPaused bool       `json:"entries_paused"`
+	PauseReason   string     `json:"pause_reason,omitempty"`
+	PausedUntil   *time.Time `json:"paused_until,omitempty"`
+	DailyPnLUSD   float64    `json:"daily_pnl_usd"` // Realized today + unrealized on open positions
+	ExposureSOL   float64    `json:"exposure_sol"`  // SOL still deployed in open positions
+	LossStreak    int        `json:"loss_streak"`
+	CheckedAt     time.Time  `json:"checked_at"`
+}
+
+// RiskGovernor enforces portfolio circuit breakers before new entries.
+// It only ever blocks buys - exits run regardless of its state.
+type RiskGovernor struct {
+	config *models.Config
+	repo   repository.Repository
+
+	mu         sync.RWMutex
+	unrealized map[string]float64 // mint -> unrealized PnL in USD, fed by the monitor
+	state      RiskState
+}
+
+func NewRiskGovernor(config *models.Config, repo repository.Repository) *RiskGovernor {
+	return &RiskGovernor{
+		config:     config,
+		repo:       repo,
+		unrealized: make(map[string]float64),
+	}
+}
+
+// RecordMark updates the unrealized PnL of an open position at the given price
+func (g *RiskGovernor) RecordMark(pos *models.Position, priceUSD float64) {
+	qty, err := strconv.ParseFloat(pos.Quantity, 64)
+	if err != nil {
+		return
+	}
+
+	// Include proceeds of earlier partial sells when the entry cost is known
+	pnl := (priceUSD - pos.AvgPriceUSD) * qty
+	if pos.CostUSD > 0 {
+		pnl = priceUSD*qty + pos.ProceedsUSD - pos.CostUSD
+	}
+
+	g.mu.Lock()
+	g.unrealized[pos.Mint] = pnl
+	g.mu.Unlock()
+}
+
+// AllowEntry re-evaluates all limits and reports whether a buy of spendSOL may go ahead
+func (g *RiskGovernor) AllowEntry(ctx context.Context, spendSOL float64) (bool, error) {
+	state, err := g.evaluate(ctx, spendSOL)
+	if err != nil {
+		return false, err
+	}
+	return !state.EntriesPaused, nil
+}
+
+// Refresh re-evaluates the limits without a pending buy
+func (g *RiskGovernor) Refresh(ctx context.Context) (RiskState, error) {
+	return g.evaluate(ctx, g.config.Trading.MaxSpendPerTrade)
+}
+
+// State returns the last computed state
+func (g *RiskGovernor) State() RiskState {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.state
+}
+
+func (g *RiskGovernor) evaluate(ctx context.Context, spendSOL float64) (RiskState, error) {
+	risk := g.config.Risk
+	now := time.Now()
+	state := RiskState{CheckedAt: now}
+
+	positions, err := g.repo.GetAllPositions(ctx)
+	if err != nil {
+		return state, fmt.Errorf("failed to get positions: %w", err)
+	}
+
+	// Drop marks for positions that have since closed
+	open := make(map[string]bool, len(positions))
+	for i := range positions {
+		open[positions[i].Mint] = true
+		state.ExposureSOL += g.deployedSOL(&positions[i])
+	}
+	g.mu.Lock()
+	for mint, pnl := range g.unrealized {
+		if !open[mint] {
+			delete(g.unrealized, mint)
+			continue
+		}
+		state.DailyPnLUSD += pnl
+	}
+	g.mu.Unlock()
+
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	today, err := g.repo.GetPnLSummary(ctx, midnight)
+	if err != nil {
+		return state, fmt.Errorf("failed to get pnl summary: %w", err)
+	}
+	state.DailyPnLUSD += today.PnLUSD
+
+	if risk.MaxConsecutiveLosses > 0 {
+		recent, err := g.repo.GetRecentRealizedPnL(ctx, risk.MaxConsecutiveLosses)
+		if err != nil {
+			return state, fmt.Errorf("failed to get recent closes: %w", err)
+		}
+		for _, r := range recent {
+			if r.PnLUSD >= 0 {
+				break
+			}
+			state.LossStreak++
+		}
+		if state.LossStreak >= risk.MaxConsecutiveLosses {
+			until := recent[0].ClosedAt.Add(time.Duration(risk.LossCooldownSec) * time.Second)
+			if now.Before(until) {
+				state.pause(fmt.Sprintf("%d consecutive losing trades, cooling down", state.LossStreak), until)
+			}
+		}
+	}
+
+	if risk.MaxDailyLossUSD > 0 && -state.DailyPnLUSD >= risk.MaxDailyLossUSD {
+		state.pause(fmt.Sprintf("daily loss $%.2f reached limit $%.2f", -state.DailyPnLUSD, risk.MaxDailyLossUSD),
+			midnight.AddDate(0, 0, 1))
+	}
+
+	if risk.MaxExposureSOL > 0 && state.ExposureSOL+spendSOL > risk.MaxExposureSOL {
+		state.pause(fmt.Sprintf("exposure %.3f SOL + %.3f SOL would exceed cap %.3f SOL",
+			state.ExposureSOL, spendSOL, risk.MaxExposureSOL), time.Time{})
+	}
+
+	g.mu.Lock()
+	prev := g.state
+	g.state = state
+	g.mu.Unlock()
+
+	if state.EntriesPaused && state.PauseReason != prev.PauseReason {
+		logger.Warn().
+			Str("reason", state.PauseReason).
+			Float64("daily_pnl_usd", state.DailyPnLUSD).
+			Float64("exposure_sol", state.ExposureSOL).
+			Int("loss_streak", state.LossStreak).
+			Msg("🛑 Risk limit hit, pausing new entries")
+	} else if !state.EntriesPaused && prev.EntriesPaused {
+		logger.Info().Msg("✅ Risk limits clear, resuming new entries")
+	}
+
+	return state, nil
+}
+
+// deployedSOL is the entry cost still tied up in a position after partial sells
+func (g *RiskGovernor) deployedSOL(pos *models.Position) float64 {
+	cost := pos.CostSOL
+	if cost == 0 {
+		cost = g.config.Trading.MaxSpendPerTrade // Legacy rows without a recorded cost
+	}
+
+	current, err := positionRawAmount(pos)
+	if err != nil || pos.InitialRaw == "" {
+		return cost
+	}
+	initial, err := strconv.ParseUint(pos.InitialRaw, 10, 64)
+	if err != nil || initial == 0 {
+		return cost
+	}
+	return cost * float64(current) / float64(initial)
+}
+
+// pause keeps the first reason that tripped; later limits don't overwrite it
+func (s *RiskState) pause(reason string, until time.Time) {
+	if s.EntriesPaused {
+		return
+	}
+	s.EntriesPaused = true
+	s.PauseReason = reason
+	if !until.IsZero() {
+		s.PausedUntil = &until
+	}
+}
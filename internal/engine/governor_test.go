@@ -0,0 +1,148 @@
+package engine
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/speier/tokenscout/internal/models"
+	"github.com/speier/tokenscout/internal/repository"
+)
+
+func newTestGovernor(t *testing.T, risk models.RiskConfig) (*RiskGovernor, *repository.SQLiteRepository) {
+	t.Helper()
+	repo, err := repository.NewSQLite(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("NewSQLite: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	config := &models.Config{Risk: risk, Trading: models.TradingConfig{MaxSpendPerTrade: 0.1}}
+	return NewRiskGovernor(config, repo), repo
+}
+
+func openTestPosition(t *testing.T, repo *repository.SQLiteRepository, pos models.Position) *models.Position {
+	t.Helper()
+	pos.OpenedAt = time.Now()
+	pos.LastUpdateAt = time.Now()
+	if err := repo.CreatePosition(context.Background(), &pos); err != nil {
+		t.Fatalf("CreatePosition(%s): %v", pos.Mint, err)
+	}
+	return &pos
+}
+
+func closeTestPosition(t *testing.T, repo *repository.SQLiteRepository, mint string, pnlUSD float64, closedAt time.Time) {
+	t.Helper()
+	entry := &models.RealizedPnL{Mint: mint, OpenedAt: closedAt.Add(-time.Minute), ClosedAt: closedAt, PnLUSD: pnlUSD}
+	if err := repo.CreateRealizedPnL(context.Background(), entry); err != nil {
+		t.Fatalf("CreateRealizedPnL(%s): %v", mint, err)
+	}
+}
+
+func TestGovernorDailyLoss(t *testing.T) {
+	ctx := context.Background()
+	governor, repo := newTestGovernor(t, models.RiskConfig{MaxDailyLossUSD: 50})
+
+	closeTestPosition(t, repo, "closed", -30, time.Now())
+	held := openTestPosition(t, repo, models.Position{Mint: "held", Quantity: "100", AvgPriceUSD: 1, CostUSD: 100})
+
+	// Realized losses alone stay under the limit
+	if ok, err := governor.AllowEntry(ctx, 0.1); err != nil || !ok {
+		t.Fatalf("AllowEntry = %v, %v, want true", ok, err)
+	}
+
+	// Marking the open position down 25 USD takes the day to -55
+	governor.RecordMark(held, 0.75)
+	ok, err := governor.AllowEntry(ctx, 0.1)
+	if err != nil || ok {
+		t.Fatalf("AllowEntry after a markdown = %v, %v, want false", ok, err)
+	}
+	state := governor.State()
+	if state.DailyPnLUSD != -55 || !strings.Contains(state.PauseReason, "daily loss") {
+		t.Errorf("state = %+v, want a daily loss pause at -55", state)
+	}
+	if state.PausedUntil == nil || state.PausedUntil.Hour() != 0 || !state.PausedUntil.After(time.Now()) {
+		t.Errorf("PausedUntil = %v, want next midnight", state.PausedUntil)
+	}
+
+	// The mark of a position that has since closed no longer counts
+	if err := repo.DeletePosition(ctx, held.Mint); err != nil {
+		t.Fatalf("DeletePosition: %v", err)
+	}
+	if ok, err := governor.AllowEntry(ctx, 0.1); err != nil || !ok {
+		t.Errorf("AllowEntry after the position closed = %v, %v, want true", ok, err)
+	}
+}
+
+func TestGovernorExposure(t *testing.T) {
+	ctx := context.Background()
+	governor, repo := newTestGovernor(t, models.RiskConfig{MaxExposureSOL: 1})
+
+	// Half of a 1 SOL position was sold, so 0.5 SOL is still deployed
+	openTestPosition(t, repo, models.Position{Mint: "half", Quantity: "500", RawAmount: "500", InitialRaw: "1000", CostSOL: 1})
+	// A legacy row without a recorded cost counts as one max-size trade
+	openTestPosition(t, repo, models.Position{Mint: "legacy", Quantity: "10"})
+
+	tests := []struct {
+		spendSOL float64
+		want     bool
+	}{
+		{0.25, true},
+		{0.4, true},
+		{0.5, false},
+	}
+	for _, tt := range tests {
+		ok, err := governor.AllowEntry(ctx, tt.spendSOL)
+		if err != nil {
+			t.Fatalf("AllowEntry(%v): %v", tt.spendSOL, err)
+		}
+		if ok != tt.want {
+			t.Errorf("AllowEntry(%v) = %v, want %v (state %+v)", tt.spendSOL, ok, tt.want, governor.State())
+		}
+	}
+	if state := governor.State(); state.ExposureSOL != 0.6 || state.PausedUntil != nil {
+		t.Errorf("state = %+v, want 0.6 SOL exposure and no pause deadline", state)
+	}
+}
+
+func TestGovernorLossStreak(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		closes []float64 // PnL in USD, oldest first, a minute apart
+		latest time.Time
+		paused bool
+		streak int
+	}{
+		{"three losses in a row", []float64{10, -1, -2, -3}, now, true, 3},
+		{"a win breaks the streak", []float64{-1, -2, 5, -3}, now, false, 1},
+		{"cooldown over", []float64{-1, -2, -3}, now.Add(-time.Hour), false, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			governor, repo := newTestGovernor(t, models.RiskConfig{MaxConsecutiveLosses: 3, LossCooldownSec: 600})
+			for i, pnl := range tt.closes {
+				closedAt := tt.latest.Add(-time.Duration(len(tt.closes)-1-i) * time.Minute)
+				closeTestPosition(t, repo, "mint", pnl, closedAt)
+			}
+
+			ok, err := governor.AllowEntry(ctx, 0.1)
+			if err != nil {
+				t.Fatalf("AllowEntry: %v", err)
+			}
+			state := governor.State()
+			if ok == tt.paused || state.LossStreak != tt.streak {
+				t.Errorf("AllowEntry = %v with a streak of %d, want paused=%v with %d", ok, state.LossStreak, tt.paused, tt.streak)
+			}
+			if tt.paused {
+				want := tt.latest.Add(600 * time.Second).Truncate(time.Second)
+				if state.PausedUntil == nil || !state.PausedUntil.Truncate(time.Second).Equal(want) {
+					t.Errorf("PausedUntil = %v, want %v", state.PausedUntil, want)
+				}
+			}
+		})
+	}
+}
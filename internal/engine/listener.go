@@ -196,7 +196,7 @@ func (l *Listener) parseEvent(logResult *ws.LogResult, program solana.PublicKey)
 	}
 
 	// Use modular parsers to extract mint from transaction
-	mint, _, found := l.extractMintFromTransaction(parsed, program)
+	init, _, found := l.extractPoolInitFromTransaction(parsed, program)
 	if !found {
 		return nil
 	}
@@ -205,13 +205,15 @@ func (l *Listener) parseEvent(logResult *ws.LogResult, program solana.PublicKey)
 
 	return &models.Event{
 		Type:      models.EventTypeNewPool,
-		Mint:      mint,
+		Mint:      init.Mint,
+		LPAddress: init.Pool,
+		LPMint:    init.LPMint,
 		Timestamp: time.Now(),
 		Raw:       toJSON(logResult),
 	}
 }
 
-func (l *Listener) extractMintFromTransaction(tx *solana.Transaction, program solana.PublicKey) (string, string, bool) {
+func (l *Listener) extractPoolInitFromTransaction(tx *solana.Transaction, program solana.PublicKey) (*PoolInit, string, bool) {
 	// Iterate through all instructions in the transaction
 	for _, instruction := range tx.Message.Instructions {
 		programID := tx.Message.AccountKeys[instruction.ProgramIDIndex]
@@ -235,13 +237,13 @@ func (l *Listener) extractMintFromTransaction(tx *solana.Transaction, program so
 			accounts = append(accounts, tx.Message.AccountKeys[accountIndex])
 		}
 
-		// Use parsers registry to extract mint
-		if mint, dexName, found := l.parsers.ParseInstruction(programID, accounts, instruction.Data); found {
-			return mint, dexName, true
+		// Use parsers registry to extract mint and pool
+		if init, dexName, found := l.parsers.ParseInstruction(programID, accounts, instruction.Data); found {
+			return init, dexName, true
 		}
 	}
 
-	return "", "", false
+	return nil, "", false
 }
 
 func (l *Listener) EventChannel() <-chan *models.Event {
@@ -14,25 +14,54 @@ import (
 
 // Monitor watches open positions and triggers exits based on rules
 type Monitor struct {
-	config        *models.Config
-	repo          repository.Repository
-	executor      *Executor
-	jupiterClient *solana.JupiterClient
+	config   *models.Config
+	repo     repository.Repository
+	executor *Executor
+	prices   *solana.PriceService
+	governor *RiskGovernor
+	stream   *PriceStream // nil when price streaming is disabled
+	danger   *DangerWatch // nil when rug checks are disabled
+	lastTick map[string]time.Time
 }
 
-func NewMonitor(config *models.Config, repo repository.Repository, executor *Executor, jupiterClient *solana.JupiterClient) *Monitor {
-	return &Monitor{
-		config:        config,
-		repo:          repo,
-		executor:      executor,
-		jupiterClient: jupiterClient,
+func NewMonitor(config *models.Config, repo repository.Repository, executor *Executor, prices *solana.PriceService, governor *RiskGovernor) *Monitor {
+	m := &Monitor{
+		config:   config,
+		repo:     repo,
+		executor: executor,
+		prices:   prices,
+		governor: governor,
+		lastTick: make(map[string]time.Time),
 	}
+	if config.Listener.PriceStream && config.Solana.WSURL != "" {
+		m.stream = NewPriceStream(config.Solana.WSURL, config.Solana.RPCURL)
+	}
+	if config.Risk.RugCheckIntervalSec > 0 {
+		wallet := ""
+		if executor.solanaClient != nil && executor.solanaClient.GetWallet() != nil {
+			wallet = executor.solanaClient.GetWallet().Address()
+		}
+		m.danger = NewDangerWatch(config, config.Solana.RPCURL, wallet)
+	}
+	return m
 }
 
 // Start begins monitoring positions
 func (m *Monitor) Start(ctx context.Context) error {
 	logger.Info().Msg("👀 Starting position monitor")
 
+	// Pool reserve changes trigger exit checks as they happen; the ticker
+	// below still covers durations and tokens the stream can't price
+	var updates <-chan string
+	if m.stream != nil {
+		updates = m.stream.Updates()
+		go func() {
+			if err := m.stream.Start(ctx); err != nil {
+				logger.Error().Err(err).Msg("Price stream error")
+			}
+		}()
+	}
+
 	ticker := time.NewTicker(5 * time.Second) // Check every 5 seconds
 	defer ticker.Stop()
 
@@ -45,20 +74,92 @@ func (m *Monitor) Start(ctx context.Context) error {
 			if err := m.checkPositions(ctx); err != nil {
 				logger.Error().Err(err).Msg("Failed to check positions")
 			}
+		case mint := <-updates:
+			m.checkStreamedPosition(ctx, mint)
 		}
 	}
 }
 
+// checkStreamedPosition runs price exits for one token after its pool changed
+func (m *Monitor) checkStreamedPosition(ctx context.Context, mint string) {
+	pos, err := m.repo.GetPosition(ctx, mint)
+	if err != nil {
+		m.stream.Unwatch(mint) // Closed since the update was queued
+		return
+	}
+	if err := m.checkPriceExits(ctx, pos); err != nil {
+		logger.Debug().Err(err).Str("mint", formatMint(mint)).Msg("Streamed price check failed")
+	}
+}
+
+// currentPrice returns the token's USD price and where it came from,
+// preferring the live pool stream and falling back to the shared price service
+func (m *Monitor) currentPrice(ctx context.Context, pos *models.Position) (float64, string, error) {
+	if m.stream != nil {
+		if priceSOL, ok := m.stream.PriceSOL(pos.Mint); ok {
+			return priceSOL * m.prices.SOLPrice(ctx).USD, "stream", nil
+		}
+	}
+	price, err := m.prices.GetPrice(ctx, pos.Mint, pos.Decimals)
+	if err != nil {
+		return 0, "", err
+	}
+	return price.USD, price.Source, nil
+}
+
 func (m *Monitor) checkPositions(ctx context.Context) error {
 	positions, err := m.repo.GetAllPositions(ctx)
 	if err != nil {
 		return err
 	}
 
+	// Keep the risk governor's view fresh even when no buys are happening
+	defer func() {
+		if _, err := m.governor.Refresh(ctx); err != nil {
+			logger.Warn().Err(err).Msg("Failed to refresh risk limits")
+		}
+	}()
+
+	open := make(map[string]bool, len(positions))
+	for i := range positions {
+		open[positions[i].Mint] = true
+		if m.stream != nil {
+			m.stream.Watch(ctx, &positions[i])
+		}
+	}
+	if m.stream != nil {
+		m.stream.Retain(open)
+	}
+	if m.danger != nil {
+		m.danger.Retain(open)
+	}
+	for mint := range m.lastTick {
+		if !open[mint] {
+			delete(m.lastTick, mint)
+		}
+	}
+
 	if len(positions) == 0 {
 		return nil
 	}
 
+	// Price every position the stream doesn't cover in one batched request;
+	// the per-position checks below then read from the cache
+	polled := make(map[string]uint8, len(positions))
+	for _, pos := range positions {
+		if m.stream != nil {
+			if _, ok := m.stream.PriceSOL(pos.Mint); ok {
+				continue
+			}
+		}
+		polled[pos.Mint] = pos.Decimals
+	}
+	if len(polled) > 0 {
+		if _, err := m.prices.GetPrices(ctx, polled); err != nil {
+			logger.Debug().Err(err).Msg("Failed to prefetch prices")
+		}
+	}
+
 	for _, pos := range positions {
 		// Check max trade duration first (always applies)
 		duration := time.Since(pos.OpenedAt)
@@ -79,6 +180,25 @@ func (m *Monitor) checkPositions(ctx context.Context) error {
 			continue
 		}
 
+		// Rug signals override every other exit rule
+		if m.danger != nil {
+			if signal := m.danger.Check(ctx, &pos); signal != nil {
+				logger.Warn().
+					Str("mint", pos.Mint).
+					Str("reason", signal.Reason).
+					Str("detail", signal.Detail).
+					Msg("🚨 Rug signal detected, selling")
+
+				if err := m.executor.ExecuteSell(ctx, pos.Mint, signal.Reason); err != nil {
+					logger.Error().
+						Err(err).
+						Str("mint", pos.Mint).
+						Msg("Failed to sell position")
+				}
+				continue
+			}
+		}
+
 		// Check price-based exits (stop-loss, take-profit)
 		if err := m.checkPriceExits(ctx, &pos); err != nil {
 			continue
@@ -90,7 +210,7 @@ func (m *Monitor) checkPositions(ctx context.Context) error {
 
 func (m *Monitor) checkPriceExits(ctx context.Context, pos *models.Position) error {
 	// Get current price
-	currentPrice, err := m.jupiterClient.GetPrice(ctx, pos.Mint)
+	currentPrice, source, err := m.currentPrice(ctx, pos)
 	if err != nil {
 		return fmt.Errorf("failed to get price: %w", err)
 	}
@@ -98,6 +218,7 @@ func (m *Monitor) checkPriceExits(ctx context.Context, pos *models.Position) err
 	if currentPrice == 0 {
 		return fmt.Errorf("invalid price: 0")
 	}
+	m.recordTick(ctx, pos.Mint, currentPrice, source)
 
 	entryPrice := pos.AvgPriceUSD
 	if entryPrice == 0 {
@@ -113,9 +234,28 @@ func (m *Monitor) checkPriceExits(ctx context.Context, pos *models.Position) err
 
 	// Calculate PnL
 	_, pnlPct := solana.CalculatePnL(entryPrice, currentPrice, qty)
+	m.governor.RecordMark(pos, currentPrice)
 
-	// Check take-profit
-	if solana.ShouldTakeProfit(entryPrice, currentPrice, m.config.Risk.TakeProfitPct) {
+	// Track the high-water mark (persisted so trailing stops survive restarts)
+	highWater := pos.HighWaterUSD
+	if highWater == 0 {
+		highWater = entryPrice
+	}
+	if currentPrice > highWater {
+		highWater = currentPrice
+		pos.HighWaterUSD = highWater
+		pos.LastUpdateAt = time.Now()
+		if err := m.repo.UpdatePosition(ctx, pos); err != nil {
+			logger.Warn().Err(err).Str("mint", pos.Mint).Msg("Failed to update high-water mark")
+		}
+	}
+
+	// Scale out through the take-profit ladder (replaces the fixed take-profit)
+	if len(m.config.Risk.TakeProfitLadder) > 0 {
+		if m.checkTakeProfitLadder(ctx, pos, pnlPct) {
+			return nil
+		}
+	} else if solana.ShouldTakeProfit(entryPrice, currentPrice, m.config.Risk.TakeProfitPct) {
 		logger.Info().
 			Str("mint", pos.Mint).
 			Float64("pnl", pnlPct).
@@ -137,7 +277,41 @@ func (m *Monitor) checkPriceExits(ctx context.Context, pos *models.Position) err
 			Float64("pnl", pnlPct).
 			Msg("📉 Stop-loss triggered, selling")
 
-		if err := m.executor.ExecuteSell(ctx, pos.Mint, "stop_loss"); err != nil {
+		if err := m.executor.ExecuteSell(ctx, pos.Mint, ExitStopLoss); err != nil {
+			logger.Error().
+				Err(err).
+				Str("mint", pos.Mint).
+				Msg("Failed to sell position")
+		}
+		return nil
+	}
+
+	// Check trailing stop
+	if solana.ShouldTrailingStop(highWater, currentPrice, m.config.Risk.TrailingStopPct) {
+		logger.Info().
+			Str("mint", pos.Mint).
+			Float64("pnl", pnlPct).
+			Float64("high_water", highWater).
+			Msg("📉 Trailing stop triggered, selling")
+
+		if err := m.executor.ExecuteSell(ctx, pos.Mint, "trailing_stop"); err != nil {
+			logger.Error().
+				Err(err).
+				Str("mint", pos.Mint).
+				Msg("Failed to sell position")
+		}
+		return nil
+	}
+
+	// Check break-even stop
+	if solana.ShouldBreakevenStop(entryPrice, highWater, currentPrice, m.config.Risk.BreakevenTriggerPct) {
+		logger.Info().
+			Str("mint", pos.Mint).
+			Float64("pnl", pnlPct).
+			Float64("high_water", highWater).
+			Msg("📉 Break-even stop triggered, selling")
+
+		if err := m.executor.ExecuteSell(ctx, pos.Mint, "breakeven_stop"); err != nil {
 			logger.Error().
 				Err(err).
 				Str("mint", pos.Mint).
@@ -148,3 +322,64 @@ func (m *Monitor) checkPriceExits(ctx context.Context, pos *models.Position) err
 
 	return nil
 }
+
+// checkTakeProfitLadder sells the next ladder tranche once its gain is reached.
+// Returns true if a sell was attempted.
+func (m *Monitor) checkTakeProfitLadder(ctx context.Context, pos *models.Position, pnlPct float64) bool {
+	ladder := m.config.Risk.TakeProfitLadder
+	if pos.TPStepsHit >= len(ladder) {
+		return false // Remainder rides on stop-loss / trailing rules
+	}
+
+	step := ladder[pos.TPStepsHit]
+	if pnlPct < step.GainPct {
+		return false
+	}
+
+	current, err := positionRawAmount(pos)
+	if err != nil || current == 0 {
+		return false
+	}
+	initial := current
+	if pos.InitialRaw != "" {
+		if v, err := strconv.ParseUint(pos.InitialRaw, 10, 64); err == nil && v > 0 {
+			initial = v
+		}
+	}
+
+	sellPct := ladderSellPct(step, initial, current)
+	reason := fmt.Sprintf("take_profit_%d", pos.TPStepsHit+1)
+
+	logger.Info().
+		Str("mint", pos.Mint).
+		Float64("pnl", pnlPct).
+		Int("step", pos.TPStepsHit+1).
+		Float64("sell_pct", step.SellPct).
+		Msg("📈 Take-profit step triggered, scaling out")
+
+	if err := m.executor.ExecutePartialSell(ctx, pos.Mint, sellPct, reason); err != nil {
+		logger.Error().
+			Err(err).
+			Str("mint", pos.Mint).
+			Msg("Failed to sell tranche")
+		return true
+	}
+
+	// Mark the step done on the reduced position (gone if this sold everything)
+	updated, err := m.repo.GetPosition(ctx, pos.Mint)
+	if err != nil {
+		return true
+	}
+	updated.TPStepsHit = pos.TPStepsHit + 1
+	if err := m.repo.UpdatePosition(ctx, updated); err != nil {
+		logger.Warn().Err(err).Str("mint", pos.Mint).Msg("Failed to record take-profit step")
+	}
+
+	return true
+}
+
+// ladderSellPct converts a ladder step, which refers to the original position
+// size, into a share of what is left. Past 100 it sells everything.
+func ladderSellPct(step models.TakeProfitStep, initial, current uint64) float64 {
+	return step.SellPct * float64(initial) / float64(current)
+}
@@ -0,0 +1,32 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/speier/tokenscout/internal/models"
+)
+
+func TestLadderSellPct(t *testing.T) {
+	tests := []struct {
+		name    string
+		sellPct float64
+		initial uint64
+		current uint64
+		want    float64
+	}{
+		{"first step on a full position", 25, 1000, 1000, 25},
+		{"second step after a quarter sold", 25, 1000, 750, 100.0 / 3},
+		{"half of the original from half left", 50, 1000, 500, 100},
+		{"more than is left sells everything", 50, 1000, 250, 200},
+		{"no recorded initial size", 30, 400, 400, 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step := models.TakeProfitStep{GainPct: 50, SellPct: tt.sellPct}
+			if got := ladderSellPct(step, tt.initial, tt.current); got != tt.want {
+				t.Errorf("ladderSellPct(%v, %d, %d) = %v, want %v", tt.sellPct, tt.initial, tt.current, got, tt.want)
+			}
+		})
+	}
+}
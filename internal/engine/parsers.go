@@ -14,13 +14,20 @@ var (
 	WrappedSOL = solana.MustPublicKeyFromBase58("So11111111111111111111111111111111111111112")
 )
 
+// PoolInit is what a parser extracts from a pool initialization instruction
+type PoolInit struct {
+	Mint   string // The new (non-SOL) token
+	Pool   string // Pool account
+	LPMint string // LP token mint; empty for pools without one (Whirlpools)
+}
+
 // InstructionParser defines the interface for DEX-specific parsers
 type InstructionParser interface {
 	// CanParse checks if this parser can handle the instruction
 	CanParse(programID solana.PublicKey, accounts []solana.PublicKey, data []byte) bool
 	
-	// ParseTokenMint extracts the new token mint from the instruction
-	ParseTokenMint(accounts []solana.PublicKey, data []byte) (string, bool)
+	// ParsePoolInit extracts the new token mint and pool accounts from the instruction
+	ParsePoolInit(accounts []solana.PublicKey, data []byte) (*PoolInit, bool)
 	
 	// Name returns the parser name for logging
 	Name() string
@@ -67,7 +74,7 @@ func (p *RaydiumParser) CanParse(programID solana.PublicKey, accounts []solana.P
 	return true
 }
 
-func (p *RaydiumParser) ParseTokenMint(accounts []solana.PublicKey, data []byte) (string, bool) {
+func (p *RaydiumParser) ParsePoolInit(accounts []solana.PublicKey, data []byte) (*PoolInit, bool) {
 	// Raydium AMM V4 initialize/initialize2 account layout:
 	// [0] Token program
 	// [1] System program
@@ -87,7 +94,7 @@ func (p *RaydiumParser) ParseTokenMint(accounts []solana.PublicKey, data []byte)
 		logger.Debug().
 			Int("accounts", len(accounts)).
 			Msg("Raydium: Not enough accounts")
-		return "", false
+		return nil, false
 	}
 	
 	tokenA := accounts[7]
@@ -98,17 +105,13 @@ func (p *RaydiumParser) ParseTokenMint(accounts []solana.PublicKey, data []byte)
 		Str("token_b", tokenB.String()).
 		Msg("Raydium: Found token pair")
 	
-	// Return the non-SOL token
-	if !tokenA.Equals(WrappedSOL) {
-		return tokenA.String(), true
-	}
-	if !tokenB.Equals(WrappedSOL) {
-		return tokenB.String(), true
-	}
-	
-	// If both are not SOL, return the first one
+	// If both are not SOL, use the first one
 	// (this might be a token-token pair)
-	return tokenA.String(), true
+	return &PoolInit{
+		Mint:   nonSOLMint(tokenA, tokenB),
+		Pool:   accounts[3].String(),
+		LPMint: accounts[6].String(),
+	}, true
 }
 
 // OrcaParser handles Orca Whirlpool pool initialization
@@ -143,7 +146,7 @@ func (p *OrcaParser) CanParse(programID solana.PublicKey, accounts []solana.Publ
 	return true
 }
 
-func (p *OrcaParser) ParseTokenMint(accounts []solana.PublicKey, data []byte) (string, bool) {
+func (p *OrcaParser) ParsePoolInit(accounts []solana.PublicKey, data []byte) (*PoolInit, bool) {
 	// Orca Whirlpool initializePool account layout:
 	// [0] WhirlpoolsConfig
 	// [1] Token mint A
@@ -155,12 +158,12 @@ func (p *OrcaParser) ParseTokenMint(accounts []solana.PublicKey, data []byte) (s
 	// [7] Fee tier
 	// ... more accounts
 	
-	// Require at least 3 accounts for safe access
-	if len(accounts) <= 2 {
+	// Require at least 5 accounts for safe access
+	if len(accounts) <= 4 {
 		logger.Debug().
 			Int("accounts", len(accounts)).
 			Msg("Orca: Not enough accounts")
-		return "", false
+		return nil, false
 	}
 	
 	tokenA := accounts[1]
@@ -171,16 +174,20 @@ func (p *OrcaParser) ParseTokenMint(accounts []solana.PublicKey, data []byte) (s
 		Str("token_b", tokenB.String()).
 		Msg("Orca: Found token pair")
 	
-	// Return the non-SOL token
-	if !tokenA.Equals(WrappedSOL) {
-		return tokenA.String(), true
-	}
-	if !tokenB.Equals(WrappedSOL) {
-		return tokenB.String(), true
+	// Whirlpool liquidity is held as position NFTs, there is no LP mint
+	return &PoolInit{
+		Mint: nonSOLMint(tokenA, tokenB),
+		Pool: accounts[4].String(),
+	}, true
+}
+
+// nonSOLMint returns the side of a pair that isn't SOL, or the first one if
+// neither is
+func nonSOLMint(tokenA, tokenB solana.PublicKey) string {
+	if tokenA.Equals(WrappedSOL) && !tokenB.Equals(WrappedSOL) {
+		return tokenB.String()
 	}
-	
-	// If both are not SOL, return the first one
-	return tokenA.String(), true
+	return tokenA.String()
 }
 
 // ParsersRegistry holds all available parsers
@@ -197,13 +204,13 @@ func NewParsersRegistry() *ParsersRegistry {
 	}
 }
 
-func (r *ParsersRegistry) ParseInstruction(programID solana.PublicKey, accounts []solana.PublicKey, data []byte) (string, string, bool) {
+func (r *ParsersRegistry) ParseInstruction(programID solana.PublicKey, accounts []solana.PublicKey, data []byte) (*PoolInit, string, bool) {
 	for _, parser := range r.parsers {
 		if parser.CanParse(programID, accounts, data) {
-			if mint, ok := parser.ParseTokenMint(accounts, data); ok {
-				return mint, parser.Name(), true
+			if init, ok := parser.ParsePoolInit(accounts, data); ok {
+				return init, parser.Name(), true
 			}
 		}
 	}
-	return "", "", false
+	return nil, "", false
 }
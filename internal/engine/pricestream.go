@@ -0,0 +1,313 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/ws"
+	"github.com/speier/tokenscout/internal/logger"
+	"github.com/speier/tokenscout/internal/models"
+	"github.com/speier/tokenscout/internal/solana"
+)
+
+// PriceStream keeps live prices for held tokens by subscribing to their pool
+// vault accounts over WebSocket and recomputing price from reserves on every
+// change. Tokens without a supported pool are left to the polling path, and
+// their pool is resolved again every poolRetryInterval.
+//
+// It holds its own connection rather than sharing the listener's. The
+// listener streams finalized logs for whole DEX programs and only exists in
+// websocket listener mode; the stream connects only while there are positions
+// to watch, and its processed-commitment updates shouldn't queue behind log
+// traffic. A drop on either connection doesn't tear down the other.
+type PriceStream struct {
+	wsURL     string
+	rpcClient *rpc.Client
+
+	mu        sync.RWMutex
+	client    *ws.Client // nil while disconnected
+	pools     map[string]*streamPool
+	updates   chan string
+	reconnect chan struct{}
+}
+
+// streamPool is the live reserve state of one held token's pool
+type streamPool struct {
+	mint         string
+	decimals     uint8
+	vaults       *solana.PoolVaults
+	supported    bool
+	solReserve   uint64
+	tokenReserve uint64
+	priceSOL     float64
+	subs         []*ws.AccountSubscription
+	resolvedAt   time.Time
+}
+
+// poolRetryInterval is how long a pool that couldn't be resolved is left to
+// polling before trying again; fresh pools are often not decodable yet
+const poolRetryInterval = time.Minute
+
+func NewPriceStream(wsURL, rpcURL string) *PriceStream {
+	return &PriceStream{
+		wsURL:     wsURL,
+		rpcClient: rpc.New(rpcURL),
+		pools:     make(map[string]*streamPool),
+		updates:   make(chan string, 100),
+		reconnect: make(chan struct{}, 1),
+	}
+}
+
+// Updates delivers the mint of every token whose pool reserves changed
+func (s *PriceStream) Updates() <-chan string {
+	return s.updates
+}
+
+// Start keeps the WebSocket connection up and resubscribes after drops
+func (s *PriceStream) Start(ctx context.Context) error {
+	logger.Debug().Str("ws_url", s.wsURL).Msg("Starting pool price stream")
+
+	for {
+		// Only hold a connection while there is something to stream
+		if s.hasSupportedPools() {
+			if err := s.connect(ctx); err != nil {
+				logger.Warn().Err(err).Msg("Price stream disconnected, falling back to polling for 5s")
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+func (s *PriceStream) connect(ctx context.Context) error {
+	client, err := ws.Connect(ctx, s.wsURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to WebSocket: %w", err)
+	}
+
+	s.mu.Lock()
+	s.client = client
+	for _, pool := range s.pools {
+		if pool.supported {
+			s.subscribeLocked(ctx, pool)
+		}
+	}
+	s.mu.Unlock()
+
+	// Drain a stale signal from an earlier connection
+	select {
+	case <-s.reconnect:
+	default:
+	}
+
+	var result error
+	select {
+	case <-ctx.Done():
+	case <-s.reconnect:
+		result = fmt.Errorf("subscription lost")
+	}
+
+	s.mu.Lock()
+	for _, pool := range s.pools {
+		s.unsubscribeLocked(pool)
+	}
+	s.client = nil
+	s.mu.Unlock()
+	client.Close()
+
+	return result
+}
+
+func (s *PriceStream) hasSupportedPools() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, pool := range s.pools {
+		if pool.supported {
+			return true
+		}
+	}
+	return false
+}
+
+// Watch starts streaming prices for an open position if its pool is supported.
+// It is safe to call repeatedly; a supported pool is only resolved once, an
+// unsupported one at most every poolRetryInterval.
+func (s *PriceStream) Watch(ctx context.Context, pos *models.Position) {
+	s.mu.RLock()
+	previous, known := s.pools[pos.Mint]
+	if known && (previous.supported || time.Since(previous.resolvedAt) < poolRetryInterval) {
+		s.mu.RUnlock()
+		return
+	}
+	s.mu.RUnlock()
+
+	pool := &streamPool{mint: pos.Mint, decimals: pos.Decimals, resolvedAt: time.Now()}
+	if err := s.resolve(ctx, pool, pos.PoolAddress); err != nil {
+		logger.Debug().
+			Err(err).
+			Str("mint", formatMint(pos.Mint)).
+			Msg("No live pool price, using polling")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if current, known := s.pools[pos.Mint]; known && current != previous {
+		return // Another Watch got there first
+	}
+	s.pools[pos.Mint] = pool
+	if pool.supported && s.client != nil {
+		s.subscribeLocked(ctx, pool)
+	}
+}
+
+// Retain stops streaming every token not in mints
+func (s *PriceStream) Retain(mints map[string]bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for mint, pool := range s.pools {
+		if !mints[mint] {
+			s.unsubscribeLocked(pool)
+			delete(s.pools, mint)
+		}
+	}
+}
+
+// Unwatch stops streaming a token
+func (s *PriceStream) Unwatch(mint string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if pool, ok := s.pools[mint]; ok {
+		s.unsubscribeLocked(pool)
+		delete(s.pools, mint)
+	}
+}
+
+// PriceSOL returns the live price in SOL; ok is false unless the pool is
+// currently subscribed and has a valid price
+func (s *PriceStream) PriceSOL(mint string) (float64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	pool, found := s.pools[mint]
+	if !found || len(pool.subs) == 0 || pool.priceSOL <= 0 {
+		return 0, false
+	}
+	return pool.priceSOL, true
+}
+
+// resolve finds the pool vaults and seeds reserves so there is a price before
+// the first notification arrives
+func (s *PriceStream) resolve(ctx context.Context, pool *streamPool, poolAddress string) error {
+	if poolAddress == "" {
+		return fmt.Errorf("no pool recorded for position")
+	}
+
+	vaults, err := solana.GetPoolVaults(ctx, s.rpcClient, poolAddress)
+	if err != nil {
+		return err
+	}
+	if !vaults.IsConstantProduct() {
+		return fmt.Errorf("pool price can't be derived from reserves")
+	}
+	solVault, tokenVault, ok := vaults.SOLSide()
+	if !ok {
+		return fmt.Errorf("pool is not paired with SOL")
+	}
+
+	solReserve, err := solana.GetTokenAccountAmount(ctx, s.rpcClient, solVault)
+	if err != nil {
+		return err
+	}
+	tokenReserve, err := solana.GetTokenAccountAmount(ctx, s.rpcClient, tokenVault)
+	if err != nil {
+		return err
+	}
+
+	pool.vaults = vaults
+	pool.supported = true
+	pool.solReserve = solReserve
+	pool.tokenReserve = tokenReserve
+	pool.priceSOL = solana.PriceFromReserves(solReserve, tokenReserve, pool.decimals)
+	return nil
+}
+
+func (s *PriceStream) subscribeLocked(ctx context.Context, pool *streamPool) {
+	solVault, tokenVault, _ := pool.vaults.SOLSide()
+
+	solSub, err := s.client.AccountSubscribe(solVault, rpc.CommitmentProcessed)
+	if err != nil {
+		logger.Debug().Err(err).Str("mint", formatMint(pool.mint)).Msg("Failed to subscribe to SOL vault")
+		return
+	}
+	tokenSub, err := s.client.AccountSubscribe(tokenVault, rpc.CommitmentProcessed)
+	if err != nil {
+		solSub.Unsubscribe()
+		logger.Debug().Err(err).Str("mint", formatMint(pool.mint)).Msg("Failed to subscribe to token vault")
+		return
+	}
+
+	pool.subs = []*ws.AccountSubscription{solSub, tokenSub}
+	go s.handleVault(ctx, pool, solSub, true)
+	go s.handleVault(ctx, pool, tokenSub, false)
+
+	logger.Debug().
+		Str("mint", formatMint(pool.mint)).
+		Str("pool", pool.vaults.Pool.String()).
+		Msg("Streaming pool reserves")
+}
+
+func (s *PriceStream) unsubscribeLocked(pool *streamPool) {
+	for _, sub := range pool.subs {
+		sub.Unsubscribe()
+	}
+	pool.subs = nil
+}
+
+func (s *PriceStream) handleVault(ctx context.Context, pool *streamPool, sub *ws.AccountSubscription, solSide bool) {
+	for {
+		got, err := sub.Recv(ctx)
+		if err != nil {
+			if ctx.Err() != nil || err == ws.ErrSubscriptionClosed {
+				return
+			}
+			// Connection-level failure; let Start reconnect everything
+			select {
+			case s.reconnect <- struct{}{}:
+			default:
+			}
+			return
+		}
+		if got == nil {
+			return // Unsubscribed
+		}
+		if got.Value == nil {
+			continue
+		}
+
+		amount, err := solana.ParseTokenAccountAmount(got.Value.Data.GetBinary())
+		if err != nil {
+			continue
+		}
+
+		s.mu.Lock()
+		if solSide {
+			pool.solReserve = amount
+		} else {
+			pool.tokenReserve = amount
+		}
+		pool.priceSOL = solana.PriceFromReserves(pool.solReserve, pool.tokenReserve, pool.decimals)
+		s.mu.Unlock()
+
+		select {
+		case s.updates <- pool.mint:
+		default:
+			// Monitor is busy; it reads the latest price when it catches up
+		}
+	}
+}
@@ -138,8 +138,7 @@ func (p *Processor) processEvent(ctx context.Context, event *models.Event) error
 	p.statsMux.Unlock()
 
 	// Evaluate rules
-	ruleEngine := NewRuleEngine(p.engine.config, p.engine.repo, p.engine.config.Solana.RPCURL)
-	decision, err := ruleEngine.Evaluate(ctx, event)
+	decision, err := p.engine.ruleEngine.Evaluate(ctx, event, p.executor.sizer)
 	if err != nil {
 		return fmt.Errorf("failed to evaluate rules: %w", err)
 	}
@@ -148,7 +147,7 @@ func (p *Processor) processEvent(ctx context.Context, event *models.Event) error
 		reason := decision.Reasons[0]
 
 		// Add to watch list if rejection is temporary (might change)
-		if p.isWatchableRejection(reason) {
+		if decision.Watchable() {
 			p.addToWatchList(event, reason)
 			// Don't count as rejected yet - we're giving it a chance
 			// Watch list addition is logged in addToWatchList
@@ -177,7 +176,7 @@ func (p *Processor) processEvent(ctx context.Context, event *models.Event) error
 	p.stats.tokensBought++
 	p.statsMux.Unlock()
 
-	if err := p.executor.ExecuteBuy(ctx, event.Mint, "rules_passed"); err != nil {
+	if err := p.executor.ExecuteBuy(ctx, event.Mint, "rules_passed", decision.Score); err != nil {
 		p.clearStatusDisplay() // Clear the rolling display
 		logger.Error().
 			Err(err).
@@ -193,29 +192,6 @@ func (p *Processor) processEvent(ctx context.Context, event *models.Event) error
 	return nil
 }
 
-// isWatchableRejection determines if a rejection reason is temporary and worth re-checking
-func (p *Processor) isWatchableRejection(reason string) bool {
-	// Watch these - they can change over time
-	watchableReasons := []string{
-		"holders:",   // Holder count can increase
-		"liquidity:", // Liquidity can increase
-		"mint age:",  // Token gets older (might become valid)
-	}
-
-	// Don't watch these - they're permanent rejections
-	// - freeze authority
-	// - mint authority
-	// - blacklisted
-	// - failed to fetch token info (likely invalid/malformed)
-
-	for _, watchable := range watchableReasons {
-		if containsIgnoreCase(reason, watchable) {
-			return true
-		}
-	}
-	return false
-}
-
 // addToWatchList adds a token to the watch list for re-evaluation
 func (p *Processor) addToWatchList(event *models.Event, reason string) {
 	p.watchMux.Lock()
@@ -262,9 +238,8 @@ func (p *Processor) recheckWatchedTokens(ctx context.Context) {
 	// Don't log every re-check, only interesting outcomes
 
 	for _, token := range tokens {
-		// Re-evaluate the token
-		ruleEngine := NewRuleEngine(p.engine.config, p.engine.repo, p.engine.config.Solana.RPCURL)
-		decision, err := ruleEngine.Evaluate(ctx, token.Event)
+		// Re-evaluate the token; its mint account and age come from the cache
+		decision, err := p.engine.ruleEngine.Evaluate(ctx, token.Event, p.executor.sizer)
 
 		p.watchMux.Lock()
 		token.LastCheckedAt = time.Now()
@@ -297,7 +272,7 @@ func (p *Processor) recheckWatchedTokens(ctx context.Context) {
 			p.stats.tokensBought++
 			p.statsMux.Unlock()
 
-			if err := p.executor.ExecuteBuy(ctx, token.Mint, "rules_passed_after_watch"); err != nil {
+			if err := p.executor.ExecuteBuy(ctx, token.Mint, "rules_passed_after_watch", decision.Score); err != nil {
 				p.clearStatusDisplay() // Clear the rolling display
 				logger.Error().
 					Err(err).
@@ -351,38 +326,6 @@ func (p *Processor) cleanupWatchList() {
 	}
 }
 
-// containsIgnoreCase checks if s contains substr (case-insensitive)
-func containsIgnoreCase(s, substr string) bool {
-	s = toLower(s)
-	substr = toLower(substr)
-	return len(s) >= len(substr) && indexIgnoreCase(s, substr) >= 0
-}
-
-func toLower(s string) string {
-	result := make([]byte, len(s))
-	for i := 0; i < len(s); i++ {
-		c := s[i]
-		if c >= 'A' && c <= 'Z' {
-			c += 'a' - 'A'
-		}
-		result[i] = c
-	}
-	return string(result)
-}
-
-func indexIgnoreCase(s, substr string) int {
-	n := len(substr)
-	if n == 0 {
-		return 0
-	}
-	for i := 0; i <= len(s)-n; i++ {
-		if s[i:i+n] == substr {
-			return i
-		}
-	}
-	return -1
-}
-
 // addEventToLog adds an event to the rolling log (keeps last 5)
 func (p *Processor) addEventToLog(eventType, mint, message string) {
 	p.eventMux.Lock()
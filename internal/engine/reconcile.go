@@ -0,0 +1,181 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/speier/tokenscout/internal/logger"
+	"github.com/speier/tokenscout/internal/repository"
+	"github.com/speier/tokenscout/internal/solana"
+)
+
+// ReconcileAction is what reconciliation does about one mismatch
+type ReconcileAction string
+
+const (
+	ReconcileAdjust ReconcileAction = "adjust" // Position quantity differs from the wallet balance
+	ReconcileClear  ReconcileAction = "clear"  // Position whose tokens are no longer in the wallet
+	ReconcileOrphan ReconcileAction = "orphan" // Tokens in the wallet without a position (flagged only)
+)
+
+// ReconcileDiff is one mismatch between the positions table and the wallet
+type ReconcileDiff struct {
+	Mint        string          `json:"mint"`
+	Action      ReconcileAction `json:"action"`
+	RecordedRaw uint64          `json:"recorded_raw"`
+	WalletRaw   uint64          `json:"wallet_raw"`
+	Decimals    uint8           `json:"decimals"`
+}
+
+// ReconcileReport is the outcome of comparing positions with wallet balances
+type ReconcileReport struct {
+	CheckedAt time.Time       `json:"checked_at"`
+	Positions int             `json:"positions"`
+	Holdings  int             `json:"holdings"`
+	Diffs     []ReconcileDiff `json:"diffs"`
+	Applied   bool            `json:"applied"`
+}
+
+// InSync reports whether positions already match the wallet
+func (r *ReconcileReport) InSync() bool {
+	return len(r.Diffs) == 0
+}
+
+// Reconciler brings the positions table back in line with on-chain balances
+// after crashes, failed sells or manual wallet actions
+type Reconciler struct {
+	repo   repository.Repository
+	client *solana.Client
+}
+
+func NewReconciler(repo repository.Repository, client *solana.Client) *Reconciler {
+	return &Reconciler{
+		repo:   repo,
+		client: client,
+	}
+}
+
+// Diff compares every open position with the wallet's token balances
+// without changing anything
+func (r *Reconciler) Diff(ctx context.Context) (*ReconcileReport, error) {
+	positions, err := r.repo.GetAllPositions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get positions: %w", err)
+	}
+
+	balances, err := r.client.GetTokenBalances(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet balances: %w", err)
+	}
+	// Wrapped SOL is working capital, not a holding
+	delete(balances, solana.WrappedSOLMint.String())
+
+	report := &ReconcileReport{
+		CheckedAt: time.Now(),
+		Positions: len(positions),
+		Holdings:  len(balances),
+	}
+
+	tracked := make(map[string]bool, len(positions))
+	for i := range positions {
+		pos := &positions[i]
+		tracked[pos.Mint] = true
+
+		recorded, err := positionRawAmount(pos)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read position %s: %w", pos.Mint, err)
+		}
+
+		held := balances[pos.Mint]
+		switch {
+		case held == 0:
+			report.Diffs = append(report.Diffs, ReconcileDiff{
+				Mint:        pos.Mint,
+				Action:      ReconcileClear,
+				RecordedRaw: recorded,
+				Decimals:    pos.Decimals,
+			})
+		case held != recorded:
+			report.Diffs = append(report.Diffs, ReconcileDiff{
+				Mint:        pos.Mint,
+				Action:      ReconcileAdjust,
+				RecordedRaw: recorded,
+				WalletRaw:   held,
+				Decimals:    pos.Decimals,
+			})
+		}
+	}
+
+	for mint, held := range balances {
+		if tracked[mint] {
+			continue
+		}
+		diff := ReconcileDiff{
+			Mint:      mint,
+			Action:    ReconcileOrphan,
+			WalletRaw: held,
+		}
+		if info, err := solana.GetTokenInfo(ctx, r.client.RPC(), mint); err == nil {
+			diff.Decimals = info.Decimals
+		}
+		report.Diffs = append(report.Diffs, diff)
+	}
+
+	sort.Slice(report.Diffs, func(i, j int) bool {
+		if report.Diffs[i].Action != report.Diffs[j].Action {
+			return report.Diffs[i].Action < report.Diffs[j].Action
+		}
+		return report.Diffs[i].Mint < report.Diffs[j].Mint
+	})
+
+	return report, nil
+}
+
+// Apply fixes quantity drift and clears positions whose tokens are gone.
+// Orphaned holdings are only logged, since their cost basis is unknown.
+func (r *Reconciler) Apply(ctx context.Context, report *ReconcileReport) error {
+	for _, diff := range report.Diffs {
+		switch diff.Action {
+		case ReconcileAdjust:
+			pos, err := r.repo.GetPosition(ctx, diff.Mint)
+			if err != nil {
+				return fmt.Errorf("failed to get position %s: %w", diff.Mint, err)
+			}
+			pos.RawAmount = strconv.FormatUint(diff.WalletRaw, 10)
+			pos.Quantity = solana.FormatUIAmount(diff.WalletRaw, pos.Decimals)
+			pos.LastUpdateAt = time.Now()
+			if err := r.repo.UpdatePosition(ctx, pos); err != nil {
+				return fmt.Errorf("failed to update position %s: %w", diff.Mint, err)
+			}
+
+			logger.Info().
+				Str("mint", formatMint(diff.Mint)).
+				Str("recorded", solana.FormatUIAmount(diff.RecordedRaw, diff.Decimals)).
+				Str("wallet", pos.Quantity).
+				Msg("🔧 Adjusted position to wallet balance")
+
+		case ReconcileClear:
+			// No sell was recorded, so there is no realized PnL to book
+			if err := r.repo.DeletePosition(ctx, diff.Mint); err != nil {
+				return fmt.Errorf("failed to delete position %s: %w", diff.Mint, err)
+			}
+
+			logger.Warn().
+				Str("mint", formatMint(diff.Mint)).
+				Str("recorded", solana.FormatUIAmount(diff.RecordedRaw, diff.Decimals)).
+				Msg("🧹 Cleared position with no tokens left in wallet")
+
+		case ReconcileOrphan:
+			logger.Warn().
+				Str("mint", diff.Mint).
+				Str("amount", solana.FormatUIAmount(diff.WalletRaw, diff.Decimals)).
+				Msg("❓ Wallet holds tokens without an open position")
+		}
+	}
+
+	report.Applied = true
+	return nil
+}
@@ -0,0 +1,165 @@
+package engine
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	solanago "github.com/gagliardetto/solana-go"
+	"github.com/speier/tokenscout/internal/models"
+	"github.com/speier/tokenscout/internal/repository"
+	"github.com/speier/tokenscout/internal/solana"
+)
+
+// walletServer answers getTokenAccountsByOwner with one token account per
+// balance, split by token program, and prices every mint at 6 decimals
+func walletServer(t *testing.T, balances map[solanago.PublicKey]map[solanago.PublicKey]uint64) *solana.Client {
+	wallet, err := solana.NewWallet()
+	if err != nil {
+		t.Fatalf("NewWallet: %v", err)
+	}
+
+	account := func(owner solanago.PublicKey, data []byte) map[string]interface{} {
+		return map[string]interface{}{
+			"lamports":   1,
+			"owner":      owner.String(),
+			"data":       []string{base64.StdEncoding.EncodeToString(data), "base64"},
+			"executable": false,
+			"rentEpoch":  0,
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("bad request: %v", err)
+			return
+		}
+
+		var result interface{}
+		switch req.Method {
+		case "getTokenAccountsByOwner":
+			var filter struct {
+				ProgramID string `json:"programId"`
+			}
+			json.Unmarshal(req.Params[1], &filter)
+			program := solanago.MustPublicKeyFromBase58(filter.ProgramID)
+
+			var value []interface{}
+			for mint, amount := range balances[program] {
+				data := make([]byte, 165)
+				copy(data[0:32], mint[:])
+				copy(data[32:64], wallet.PublicKey[:])
+				binary.LittleEndian.PutUint64(data[64:72], amount)
+				value = append(value, map[string]interface{}{
+					"pubkey":  solanago.NewWallet().PublicKey().String(),
+					"account": account(program, data),
+				})
+			}
+			result = map[string]interface{}{"context": map[string]interface{}{"slot": 1}, "value": value}
+		case "getMultipleAccounts":
+			data := make([]byte, 82)
+			data[44] = 6 // Decimals
+			data[45] = 1 // Initialized
+			result = map[string]interface{}{"context": map[string]interface{}{"slot": 1}, "value": []interface{}{account(solanago.TokenProgramID, data), nil}}
+		default:
+			t.Errorf("unexpected method %s", req.Method)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+	t.Cleanup(server.Close)
+	return solana.NewClient(server.URL, wallet)
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	repo, err := repository.NewSQLite(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("NewSQLite: %v", err)
+	}
+	defer repo.Close()
+
+	same := solanago.NewWallet().PublicKey()
+	drift := solanago.NewWallet().PublicKey()
+	gone := solanago.NewWallet().PublicKey()
+	orphan := solanago.NewWallet().PublicKey()
+
+	for mint, raw := range map[solanago.PublicKey]string{same: "100", drift: "100", gone: "50"} {
+		pos := &models.Position{Mint: mint.String(), Quantity: "0.0001", RawAmount: raw, InitialRaw: raw, Decimals: 6, OpenedAt: time.Now(), LastUpdateAt: time.Now()}
+		if err := repo.CreatePosition(ctx, pos); err != nil {
+			t.Fatalf("CreatePosition: %v", err)
+		}
+	}
+
+	client := walletServer(t, map[solanago.PublicKey]map[solanago.PublicKey]uint64{
+		solanago.TokenProgramID: {
+			same:                  100,
+			drift:                 80,
+			solana.WrappedSOLMint: 5000, // Working capital, never a holding
+		},
+		solanago.Token2022ProgramID: {orphan: 7},
+	})
+	reconciler := NewReconciler(repo, client)
+
+	report, err := reconciler.Diff(ctx)
+	if err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+	want := []ReconcileDiff{
+		{Mint: drift.String(), Action: ReconcileAdjust, RecordedRaw: 100, WalletRaw: 80, Decimals: 6},
+		{Mint: gone.String(), Action: ReconcileClear, RecordedRaw: 50, Decimals: 6},
+		{Mint: orphan.String(), Action: ReconcileOrphan, WalletRaw: 7, Decimals: 6},
+	}
+	if report.Positions != 3 || report.Holdings != 3 || len(report.Diffs) != len(want) {
+		t.Fatalf("Diff = %+v, want 3 positions, 3 holdings and %d diffs", report, len(want))
+	}
+	for i := range want {
+		if report.Diffs[i] != want[i] {
+			t.Errorf("diff %d = %+v, want %+v", i, report.Diffs[i], want[i])
+		}
+	}
+
+	if err := reconciler.Apply(ctx, report); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if !report.Applied {
+		t.Error("Apply left the report unapplied")
+	}
+
+	positions, err := repo.GetAllPositions(ctx)
+	if err != nil {
+		t.Fatalf("GetAllPositions: %v", err)
+	}
+	byMint := make(map[string]models.Position)
+	for _, pos := range positions {
+		byMint[pos.Mint] = pos
+	}
+	if len(byMint) != 2 {
+		t.Errorf("positions after Apply = %v, want same and drift only", positions)
+	}
+	if pos := byMint[drift.String()]; pos.RawAmount != "80" || pos.Quantity != "0.000080" || pos.InitialRaw != "100" {
+		t.Errorf("adjusted position = %s (%s raw, %s initial), want 0.000080 (80 raw, 100 initial)", pos.Quantity, pos.RawAmount, pos.InitialRaw)
+	}
+	if pos := byMint[same.String()]; pos.RawAmount != "100" {
+		t.Errorf("position in sync changed to %s raw", pos.RawAmount)
+	}
+
+	// Nothing left to fix but the orphan, which only gets flagged
+	report, err = reconciler.Diff(ctx)
+	if err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+	if len(report.Diffs) != 1 || report.Diffs[0].Action != ReconcileOrphan {
+		t.Errorf("Diff after Apply = %+v, want only the orphan", report.Diffs)
+	}
+}
@@ -0,0 +1,258 @@
+package engine
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/speier/tokenscout/internal/logger"
+	"github.com/speier/tokenscout/internal/models"
+	"github.com/speier/tokenscout/internal/solana"
+)
+
+// pendingTradeGrace is how old a PENDING trade must be before recovery touches
+// it. A swap's blockhash has long expired by then, so its outcome is final.
+const pendingTradeGrace = 2 * time.Minute
+
+// RecoverPendingTrades settles trades left PENDING by a crash or restart.
+// Trades with a signature are looked up on chain and marked EXECUTED or
+// FAILED; confirmed buys get their position created and confirmed sells
+// reduce or close theirs. Trades that never got a signature were never sent.
+func (e *Executor) RecoverPendingTrades(ctx context.Context) error {
+	trades, err := e.repo.GetPendingTrades(ctx, time.Now().Add(-pendingTradeGrace))
+	if err != nil {
+		return fmt.Errorf("failed to get pending trades: %w", err)
+	}
+	if len(trades) == 0 {
+		return nil
+	}
+
+	logger.Info().Int("count", len(trades)).Msg("🩹 Recovering pending trades")
+
+	for i := range trades {
+		if err := e.recoverTrade(ctx, &trades[i]); err != nil {
+			// Left PENDING, the next start tries again
+			logger.Error().
+				Err(err).
+				Int64("trade_id", trades[i].ID).
+				Str("mint", trades[i].Mint).
+				Msg("Failed to recover pending trade")
+		}
+	}
+	return nil
+}
+
+func (e *Executor) recoverTrade(ctx context.Context, trade *models.Trade) error {
+	sig, err := solana.ParseSignature(trade.TxSig)
+	if err != nil {
+		// The process died before the swap was signed and sent
+		return e.settleRecoveredTrade(ctx, trade, models.TradeStatusFailed, "never sent")
+	}
+
+	result, err := e.solanaClient.GetTransactionStatus(ctx, sig)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return e.settleRecoveredTrade(ctx, trade, models.TradeStatusFailed, "never landed")
+	}
+	if result.Outcome != solana.TxConfirmed {
+		return e.settleRecoveredTrade(ctx, trade, models.TradeStatusFailed, string(result.Outcome))
+	}
+
+	switch trade.Side {
+	case models.TradeSideBuy:
+		err = e.recoverBuy(ctx, trade)
+	case models.TradeSideSell:
+		err = e.recoverSell(ctx, trade)
+	}
+	if err != nil {
+		return err
+	}
+
+	return e.settleRecoveredTrade(ctx, trade, models.TradeStatusExecuted, "confirmed on chain")
+}
+
+func (e *Executor) settleRecoveredTrade(ctx context.Context, trade *models.Trade, status models.TradeStatus, detail string) error {
+	if err := e.repo.UpdateTradeStatus(ctx, trade.ID, status, trade.TxSig); err != nil {
+		return fmt.Errorf("failed to update trade: %w", err)
+	}
+
+	logger.Info().
+		Int64("trade_id", trade.ID).
+		Str("side", string(trade.Side)).
+		Str("mint", formatMint(trade.Mint)).
+		Str("status", string(status)).
+		Str("detail", detail).
+		Msg("Recovered pending trade")
+	return nil
+}
+
+// recoverBuy opens the position for a buy that landed after we lost track of it
+func (e *Executor) recoverBuy(ctx context.Context, trade *models.Trade) error {
+	_, err := e.repo.GetPosition(ctx, trade.Mint)
+	if err == nil {
+		return nil // Position was created before the crash
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to get position: %w", err)
+	}
+	if e.solanaClient.GetWallet() == nil {
+		return fmt.Errorf("no wallet loaded to read the bought amount")
+	}
+
+	// What the swap delivered; the wallet total would also count tokens it
+	// already held, so it is only used when the transaction can't be read
+	var held uint64
+	received, err := e.swapTokenChange(ctx, trade.TxSig, trade.Mint)
+	if err != nil {
+		logger.Warn().Err(err).Str("mint", trade.Mint).Msg("Failed to read swap token change, using wallet balance")
+		held, err = e.solanaClient.GetTokenBalance(ctx, trade.Mint)
+		if err != nil {
+			return fmt.Errorf("failed to get token balance: %w", err)
+		}
+	} else if received > 0 {
+		held = uint64(received)
+	}
+	if held == 0 {
+		logger.Warn().
+			Str("mint", formatMint(trade.Mint)).
+			Msg("Recovered buy left no tokens in the wallet, not opening a position")
+		return nil
+	}
+
+	tokenInfo, err := solana.GetTokenInfo(ctx, e.solanaClient.RPC(), trade.Mint)
+	if err != nil {
+		return fmt.Errorf("failed to fetch token info: %w", err)
+	}
+
+	// Prefer the SOL that actually left the wallet; the trade row only has
+	// the amount we asked to spend
+	solSpent, _ := strconv.ParseFloat(trade.Quantity, 64)
+	var feeLamports uint64
+	if sig, err := solana.ParseSignature(trade.TxSig); err == nil {
+		if change, fee, err := e.solanaClient.GetTransactionSOLChange(ctx, sig); err == nil && -change > int64(fee) {
+			solSpent = solana.ConvertLamportsToSOL(uint64(-change) - fee)
+			feeLamports = fee
+		}
+	}
+
+	solPrice := e.solPriceUSD(ctx)
+	usdSpent := solSpent * solPrice
+	tokenPriceUSD := usdSpent / solana.ToUIAmount(held, tokenInfo.Decimals)
+
+	if err := e.repo.UpdateTradeFill(ctx, trade.ID, solana.FormatUIAmount(held, tokenInfo.Decimals), strconv.FormatUint(held, 10), tokenPriceUSD, 0); err != nil {
+		return fmt.Errorf("failed to update trade: %w", err)
+	}
+
+	position := &models.Position{
+		Mint:         trade.Mint,
+		Symbol:       tokenSymbol(tokenInfo),
+		Quantity:     solana.FormatUIAmount(held, tokenInfo.Decimals),
+		RawAmount:    strconv.FormatUint(held, 10),
+		InitialRaw:   strconv.FormatUint(held, 10),
+		Decimals:     tokenInfo.Decimals,
+		AvgPriceUSD:  tokenPriceUSD,
+		HighWaterUSD: tokenPriceUSD,
+		CostSOL:      solSpent,
+		CostUSD:      usdSpent,
+		FeesSOL:      solana.ConvertLamportsToSOL(feeLamports),
+		OpenedAt:     trade.Timestamp,
+		LastUpdateAt: time.Now(),
+		Strategy:     trade.Strategy,
+	}
+	if err := e.repo.CreatePosition(ctx, position); err != nil {
+		return fmt.Errorf("failed to create position: %w", err)
+	}
+
+	logger.Info().
+		Str("mint", formatMint(trade.Mint)).
+		Str("symbol", tokenSymbol(tokenInfo)).
+		Float64("entry_price", tokenPriceUSD).
+		Msg("📈 Position opened from recovered buy")
+
+	e.trackDeployer(ctx, trade.Mint)
+	return nil
+}
+
+// recoverSell applies a sell that landed after we lost track of it
+func (e *Executor) recoverSell(ctx context.Context, trade *models.Trade) error {
+	position, err := e.repo.GetPosition(ctx, trade.Mint)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil // Already closed, nothing left to update
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get position: %w", err)
+	}
+
+	positionUnits, err := positionRawAmount(position)
+	if err != nil {
+		return err
+	}
+	soldUnits, err := strconv.ParseUint(trade.RawAmount, 10, 64)
+	if err != nil {
+		return fmt.Errorf("failed to parse sold amount: %w", err)
+	}
+
+	received, feeLamports, err := e.swapSOLChange(ctx, trade.TxSig)
+	if err != nil {
+		return fmt.Errorf("failed to read sell proceeds: %w", err)
+	}
+	solReceived := 0.0
+	if received > 0 {
+		solReceived = solana.ConvertLamportsToSOL(uint64(received))
+	}
+
+	solPrice := e.solPriceUSD(ctx)
+	usdReceived := solReceived * solPrice
+
+	soldQty := solana.ToUIAmount(soldUnits, position.Decimals)
+	exitPriceUSD := 0.0
+	if soldQty > 0 {
+		exitPriceUSD = usdReceived / soldQty
+	}
+	pnlUSD := usdReceived - position.AvgPriceUSD*soldQty
+	if err := e.repo.UpdateTradeFill(ctx, trade.ID, trade.Quantity, trade.RawAmount, exitPriceUSD, pnlUSD); err != nil {
+		return fmt.Errorf("failed to update trade: %w", err)
+	}
+
+	position.ProceedsSOL += solReceived
+	position.ProceedsUSD += usdReceived
+	position.FeesSOL += solana.ConvertLamportsToSOL(feeLamports)
+
+	if soldUnits < positionUnits {
+		remaining := positionUnits - soldUnits
+		position.RawAmount = strconv.FormatUint(remaining, 10)
+		position.Quantity = solana.FormatUIAmount(remaining, position.Decimals)
+		position.LastUpdateAt = time.Now()
+		if err := e.repo.UpdatePosition(ctx, position); err != nil {
+			return fmt.Errorf("failed to update position: %w", err)
+		}
+
+		logger.Info().
+			Str("mint", formatMint(trade.Mint)).
+			Float64("pnl_usd", pnlUSD).
+			Str("remaining", position.Quantity).
+			Msg("📉 Position reduced from recovered sell")
+		return nil
+	}
+
+	entry := newRealizedPnL(position, solPrice, trade.Reason)
+	e.applyExcursions(ctx, entry, position, exitPriceUSD)
+	if err := e.repo.CreateRealizedPnL(ctx, entry); err != nil {
+		logger.Error().Err(err).Str("mint", trade.Mint).Msg("Failed to record realized PnL")
+	}
+	e.judgeDeployer(ctx, entry)
+	if err := e.repo.DeletePosition(ctx, trade.Mint); err != nil {
+		return fmt.Errorf("failed to delete position: %w", err)
+	}
+
+	logger.Info().
+		Str("mint", formatMint(trade.Mint)).
+		Float64("pnl_usd", entry.PnLUSD).
+		Msg("📉 Position closed from recovered sell")
+	return nil
+}
@@ -0,0 +1,34 @@
+package engine
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/speier/tokenscout/internal/models"
+	"github.com/speier/tokenscout/internal/repository"
+)
+
+func TestRecoverPositionLookup(t *testing.T) {
+	ctx := context.Background()
+	repo, err := repository.NewSQLite(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("NewSQLite: %v", err)
+	}
+	executor := &Executor{repo: repo}
+	trade := &models.Trade{Side: models.TradeSideSell, Mint: "closed", RawAmount: "100"}
+
+	// No position left means it was already closed
+	if err := executor.recoverSell(ctx, trade); err != nil {
+		t.Errorf("recoverSell without a position = %v, want nil", err)
+	}
+
+	// Any other lookup failure must not be mistaken for a closed position
+	repo.Close()
+	if err := executor.recoverSell(ctx, trade); err == nil {
+		t.Error("recoverSell with a failing repository = nil, want an error")
+	}
+	if err := executor.recoverBuy(ctx, trade); err == nil {
+		t.Error("recoverBuy with a failing repository = nil, want an error")
+	}
+}
@@ -2,36 +2,87 @@ package engine
 
 import (
 	"context"
-	"fmt"
-	"strconv"
+	"math"
+	"sync"
 
 	"github.com/gagliardetto/solana-go/rpc"
 	"github.com/speier/tokenscout/internal/logger"
 	"github.com/speier/tokenscout/internal/models"
 	"github.com/speier/tokenscout/internal/repository"
-	"github.com/speier/tokenscout/internal/solana"
 )
 
 type Decision struct {
-	Allow   bool     `json:"allow"`
-	Reasons []string `json:"reasons"`
+	Allow    bool         `json:"allow"`
+	Reasons  []string     `json:"reasons"`            // Failing blocking rules, first failure first
+	Warnings []string     `json:"warnings,omitempty"` // Failing warn-only rules
+	Results  []RuleResult `json:"results"`            // Every rule that ran
+	Score    float64      `json:"score"`              // 0-1 conviction; how comfortably the token cleared the rules
 }
 
+// Watchable reports whether a rejection may clear later: every blocking
+// failure came from a watch-severity rule
+func (d *Decision) Watchable() bool {
+	if d.Allow {
+		return false
+	}
+	for _, result := range d.Results {
+		if !result.Pass && result.Severity == SeverityCritical {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *Decision) reject(reason string) {
+	d.Allow = false
+	for _, existing := range d.Reasons {
+		if existing == reason {
+			return // Several rules share a failed fetch
+		}
+	}
+	d.Reasons = append(d.Reasons, reason)
+}
+
+// RuleEngine evaluates tokens against the configured rules. It is long-lived:
+// the RPC client and the cache of stable token facts are shared by every
+// evaluation.
 type RuleEngine struct {
-	config    *models.Config
 	repo      repository.Repository
 	rpcClient *rpc.Client
+	cache     *factCache
+
+	mu     sync.RWMutex
+	config *models.Config
+	rules  []Rule
 }
 
 func NewRuleEngine(config *models.Config, repo repository.Repository, rpcURL string) *RuleEngine {
 	return &RuleEngine{
-		config:    config,
 		repo:      repo,
 		rpcClient: rpc.New(rpcURL),
+		cache:     newFactCache(factCacheTTL),
+		config:    config,
+		rules:     buildRules(config),
 	}
 }
 
-func (r *RuleEngine) Evaluate(ctx context.Context, event *models.Event) (*Decision, error) {
+// Reload rebuilds the rules from config. Evaluations already running finish
+// with the old rules; cached facts are kept.
+func (r *RuleEngine) Reload(config *models.Config) {
+	rules := buildRules(config)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.config = config
+	r.rules = rules
+}
+
+// Evaluate runs the configured rules in order. By default it stops at the
+// first blocking failure; with rules.full_report every rule runs and the
+// decision lists all failures. Facts are fetched concurrently: see
+// prefetchInputs. sizer decides how big a buy the rules judge, e.g. the
+// round trip the honeypot rule quotes; nil means max_spend_per_trade.
+func (r *RuleEngine) Evaluate(ctx context.Context, event *models.Event, sizer PositionSizer) (*Decision, error) {
 	decision := &Decision{
 		Allow:   true,
 		Reasons: []string{},
@@ -43,97 +94,75 @@ func (r *RuleEngine) Evaluate(ctx context.Context, event *models.Event) (*Decisi
 		return decision, nil
 	}
 
-	// Check blacklist
-	blacklisted, err := r.repo.IsBlacklisted(ctx, event.Mint)
-	if err != nil {
-		return nil, fmt.Errorf("failed to check blacklist: %w", err)
-	}
-	if blacklisted {
-		decision.Allow = false
-		decision.Reasons = append(decision.Reasons, "mint is blacklisted")
-		return decision, nil
-	}
+	r.mu.RLock()
+	config, rules := r.config, r.rules
+	r.mu.RUnlock()
 
-	// Fetch token info
-	tokenInfo, err := solana.GetTokenInfo(ctx, r.rpcClient, event.Mint)
-	if err != nil {
-		decision.Allow = false
-		decision.Reasons = append(decision.Reasons, "failed to fetch token info")
-		return decision, nil
-	}
+	facts := newTokenFacts(event, config, r.repo, r.rpcClient, r.cache, sizer)
 
-	// Check freeze authority
-	if r.config.Rules.BlockFreezeAuthority && tokenInfo.HasFreezeAuthority {
-		decision.Allow = false
-		decision.Reasons = append(decision.Reasons, "has freeze authority")
-		return decision, nil
-	}
-
-	// Check mint authority
-	if !r.config.Rules.AllowMintAuthority && tokenInfo.HasMintAuthority {
-		decision.Allow = false
-		decision.Reasons = append(decision.Reasons, "has mint authority")
-		return decision, nil
-	}
-
-	// Check holder count and distribution
-	holders, err := solana.GetTokenHolders(ctx, r.rpcClient, event.Mint)
-	if err != nil {
-		decision.Allow = false
-		decision.Reasons = append(decision.Reasons, "failed to fetch holders")
-		return decision, nil
-	}
-	
-	holderCount, topHolderPct, _ := solana.AnalyzeHolderDistribution(holders)
-	
-	// Check minimum holders
-	if holderCount < r.config.Rules.MinHolders {
-		decision.Allow = false
-		decision.Reasons = append(decision.Reasons, 
-			fmt.Sprintf("holders: %d < %d", holderCount, r.config.Rules.MinHolders))
-		return decision, nil
-	}
+	// Fetches for rules that never run are abandoned on return
+	fetchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	fetchedTier := -1
 
-	// Check dev wallet concentration
-	if topHolderPct > r.config.Rules.DevWalletMaxPct {
-		decision.Allow = false
-		decision.Reasons = append(decision.Reasons, 
-			fmt.Sprintf("top holder: %.1f%% > %.1f%%", topHolderPct, r.config.Rules.DevWalletMaxPct))
-		return decision, nil
-	}
+	// Headroom on each threshold feeds the conviction score
+	var scores []float64
+	for i, rule := range rules {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		fetchedTier = prefetchInputs(fetchCtx, facts, rules[i:], fetchedTier, config.Rules.FullReport)
+
+		verdict := rule.Check(ctx, facts)
+		decision.Results = append(decision.Results, RuleResult{
+			Rule:     rule.Name(),
+			Severity: rule.Severity(),
+			Pass:     verdict.Pass,
+			Reason:   verdict.Reason,
+		})
+
+		if verdict.Pass {
+			if verdict.Scored {
+				scores = append(scores, verdict.Score)
+			}
+			continue
+		}
+		if !rule.Severity().Blocks() {
+			decision.Warnings = append(decision.Warnings, verdict.Reason)
+			continue
+		}
 
-	// Check token age
-	if r.config.Rules.MaxMintAgeSec > 0 {
-		tooOld, ageSeconds, err := solana.IsTokenTooOld(ctx, r.rpcClient, event.Mint, int64(r.config.Rules.MaxMintAgeSec))
-		if err == nil && tooOld {
-			decision.Allow = false
-			decision.Reasons = append(decision.Reasons, 
-				fmt.Sprintf("too old: %ds", ageSeconds))
-			return decision, nil
+		decision.reject(verdict.Reason)
+		if !config.Rules.FullReport {
+			break
 		}
 	}
 
-	// TODO: Check liquidity amount (min_liquidity_usd) - requires DEX pool query
-	
-	// HONEYPOT DETECTION: Simulate sell to verify token is sellable
-	// This is CRITICAL for snipe & flip - many scam tokens allow buy but block sell
-	if err := r.checkHoneypot(ctx, event.Mint); err != nil {
-		decision.Allow = false
-		decision.Reasons = append(decision.Reasons, 
-			fmt.Sprintf("honeypot detected: %s", err.Error()))
-		return decision, nil
-	}
+	decision.Score = averageScore(scores)
 
 	logger.Debug().
 		Str("mint", event.Mint).
+		Str("symbol", facts.symbol()).
 		Bool("allow", decision.Allow).
 		Strs("reasons", decision.Reasons).
+		Strs("warnings", decision.Warnings).
+		Float64("score", decision.Score).
 		Msg("Rule evaluation complete")
 
+	for _, warning := range decision.Warnings {
+		logger.Info().
+			Str("mint", formatMint(event.Mint)).
+			Str("symbol", facts.symbol()).
+			Str("warning", warning).
+			Msg("⚠️  Rule warning")
+	}
+
 	if !decision.Allow {
 		logger.Info().
 			Str("mint", formatMint(event.Mint)).
+			Str("symbol", facts.symbol()).
 			Str("reason", decision.Reasons[0]).
+			Int("failed", len(decision.Reasons)).
 			Msg("❌ Rejected")
 		logger.Debug().
 			Strs("all_reasons", decision.Reasons).
@@ -141,95 +170,80 @@ func (r *RuleEngine) Evaluate(ctx context.Context, event *models.Event) (*Decisi
 	} else {
 		logger.Info().
 			Str("mint", formatMint(event.Mint)).
+			Str("symbol", facts.symbol()).
 			Msg("✅ Passed all checks")
 	}
 
 	return decision, nil
 }
 
-// checkHoneypot simulates a sell transaction to verify the token is sellable
-// Many scam tokens allow buys but block sells - this catches them before we buy
-func (r *RuleEngine) checkHoneypot(ctx context.Context, mint string) error {
-	logger.Debug().
-		Str("mint", formatMint(mint)).
-		Msg("Checking for honeypot (simulating sell)")
-	
-	// Create Jupiter client for quote
-	jupiterClient := solana.NewJupiterClient(r.config.Solana.JupiterAPIURL)
-	
-	// Simulate selling a small amount: token -> SOL
-	// Use a minimal amount (0.001 SOL equivalent) just to test if swap is possible
-	testAmount := uint64(1000000) // 0.001 SOL worth of tokens (approximate)
-	
-	quoteReq := solana.QuoteRequest{
-		InputMint:  mint,                                        // Token we want to sell
-		OutputMint: "So11111111111111111111111111111111111111112", // SOL (wrapped)
-		Amount:     testAmount,
-		SlippageBps: 500, // 5% slippage for test
-	}
-	
-	// Try to get a quote for the reverse swap
-	quote, err := jupiterClient.GetQuote(ctx, quoteReq)
-	if err != nil {
-		return fmt.Errorf("cannot get sell quote (likely honeypot)")
-	}
-	
-	if quote == nil || quote.OutAmount == "" {
-		return fmt.Errorf("no sell route available (likely honeypot)")
-	}
-	
-	// Parse the output amount to verify it's reasonable
-	outAmount, err := strconv.ParseUint(quote.OutAmount, 10, 64)
-	if err != nil {
-		return fmt.Errorf("invalid sell quote")
-	}
-	
-	// Sanity check: output should be > 0
-	if outAmount == 0 {
-		return fmt.Errorf("zero output on sell (likely honeypot)")
-	}
-	
-	logger.Debug().
-		Str("mint", formatMint(mint)).
-		Uint64("out_amount", outAmount).
-		Msg("✓ Honeypot check passed (token is sellable)")
-	
-	return nil
-}
-
-// TokenInfo represents metadata about a token
-type TokenInfo struct {
-	Mint              string
-	Name              string
-	Symbol            string
-	Decimals          uint8
-	Supply            uint64
-	HasFreezeAuthority bool
-	HasMintAuthority   bool
+// factTiers orders facts by cost. The mint account, creation time and
+// deployer are a few cheap calls and reject most tokens; holder and pool
+// scans are heavy, and tracing holder clusters is heavier still.
+var factTiers = map[FactKind]int{
+	FactTokenInfo:      0,
+	FactMintAge:        0,
+	FactDeployer:       0,
+	FactHolders:        1,
+	FactPool:           1,
+	FactTradeSize:      1,
+	FactHolderClusters: 2,
 }
 
-// TODO: Implement these helper functions
-func (r *RuleEngine) fetchTokenMetadata(ctx context.Context, mint string) (*TokenInfo, error) {
-	// TODO: Fetch from Metaplex or direct account queries
-	return nil, fmt.Errorf("not implemented")
+// prefetchInputs starts fetching facts before the next rule runs. When the
+// rule needs a costlier tier than has been started, every fact up to that
+// tier that this or a later rule reads is fetched concurrently. A token
+// rejected by a cheap rule never pays for the heavy fetches, and one that
+// gets that far waits for them once instead of once per rule. With
+// fullReport every rule runs anyway, so everything is fetched at once.
+// It returns the highest tier started.
+func prefetchInputs(ctx context.Context, facts *TokenFacts, rules []Rule, fetchedTier int, fullReport bool) int {
+	kinds, tier := tierInputs(rules, fetchedTier, fullReport)
+	facts.prefetch(ctx, kinds)
+	return tier
 }
 
-func (r *RuleEngine) checkLiquidity(ctx context.Context, mint string) (float64, error) {
-	// TODO: Query DEX pool for liquidity amount
-	return 0, fmt.Errorf("not implemented")
-}
-
-func (r *RuleEngine) checkHolderCount(ctx context.Context, mint string) (int, error) {
-	// TODO: Query token accounts to count holders
-	return 0, fmt.Errorf("not implemented")
-}
+// tierInputs picks the facts prefetchInputs starts for rules[0], and the
+// tier they reach. Nothing is picked when that tier has already started.
+func tierInputs(rules []Rule, fetchedTier int, fullReport bool) ([]FactKind, int) {
+	tier := -1
+	for _, kind := range rules[0].Inputs() {
+		if factTiers[kind] > tier {
+			tier = factTiers[kind]
+		}
+	}
+	if fullReport {
+		for _, t := range factTiers {
+			if t > tier {
+				tier = t
+			}
+		}
+	}
+	if tier <= fetchedTier {
+		return nil, fetchedTier
+	}
 
-func (r *RuleEngine) checkDevWalletConcentration(ctx context.Context, mint string) (float64, error) {
-	// TODO: Analyze top holder wallets
-	return 0, fmt.Errorf("not implemented")
+	var kinds []FactKind
+	seen := make(map[FactKind]bool)
+	for _, rule := range rules {
+		for _, kind := range rule.Inputs() {
+			if factTiers[kind] <= tier && !seen[kind] {
+				seen[kind] = true
+				kinds = append(kinds, kind)
+			}
+		}
+	}
+	return kinds, tier
 }
 
-func (r *RuleEngine) simulateSell(ctx context.Context, mint string, amount uint64) (bool, error) {
-	// TODO: Try to get a sell quote - if it fails, might be a honeypot
-	return false, fmt.Errorf("not implemented")
+// averageScore clamps each component to 0-1 and averages them (1 when there are none)
+func averageScore(scores []float64) float64 {
+	if len(scores) == 0 {
+		return 1
+	}
+	total := 0.0
+	for _, s := range scores {
+		total += math.Max(0, math.Min(1, s))
+	}
+	return total / float64(len(scores))
 }
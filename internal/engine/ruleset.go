@@ -0,0 +1,168 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/speier/tokenscout/internal/logger"
+	"github.com/speier/tokenscout/internal/models"
+)
+
+// RuleSeverity decides what a failing rule does to the decision
+type RuleSeverity string
+
+const (
+	SeverityCritical RuleSeverity = "critical" // Rejects; the token won't get better
+	SeverityWatch    RuleSeverity = "watch"    // Rejects, but the token goes on the watch list for re-checks
+	SeverityWarn     RuleSeverity = "warn"     // Reported only, never rejects
+)
+
+// Blocks reports whether a failure at this severity rejects the token
+func (s RuleSeverity) Blocks() bool {
+	return s != SeverityWarn
+}
+
+// FactKind names a piece of token data rules read from TokenFacts
+type FactKind string
+
+const (
+	FactTokenInfo FactKind = "token_info" // Mint account, authorities, extensions, metadata
+	FactHolders   FactKind = "holders"    // Wallet balances, pools and burns excluded
+	FactMintAge   FactKind = "mint_age"   // Time since the mint's first transaction
+	FactPool      FactKind = "pool"       // Deepest priced pool and its reserves
+	FactDeployer  FactKind = "deployer"   // Mint creator and funder wallets
+	FactTradeSize FactKind = "trade_size" // SOL a buy would spend
+
+	FactHolderClusters FactKind = "holder_clusters" // Top holders funded by one wallet around launch
+)
+
+// Rule is one entry check. Rules read what they need from the shared facts
+// and return a verdict; failing to fetch a fact is the rule's call.
+type Rule interface {
+	Name() string
+	Inputs() []FactKind // Facts the rule reads, so they can be fetched up front
+	Severity() RuleSeverity
+	Check(ctx context.Context, facts *TokenFacts) RuleVerdict
+}
+
+// RuleVerdict is the outcome of one rule
+type RuleVerdict struct {
+	Pass   bool
+	Reason string  // Why it failed, e.g. "holders: 2 < 3"
+	Score  float64 // 0-1 headroom on the threshold, when Scored
+	Scored bool
+}
+
+// RulePass is a passing verdict without a score
+func RulePass() RuleVerdict {
+	return RuleVerdict{Pass: true}
+}
+
+// RulePassScored is a passing verdict that feeds the conviction score
+func RulePassScored(score float64) RuleVerdict {
+	return RuleVerdict{Pass: true, Score: score, Scored: true}
+}
+
+// RuleFail is a failing verdict
+func RuleFail(format string, args ...interface{}) RuleVerdict {
+	return RuleVerdict{Reason: fmt.Sprintf(format, args...)}
+}
+
+// RuleResult is a rule's verdict as recorded on a decision
+type RuleResult struct {
+	Rule     string       `json:"rule"`
+	Severity RuleSeverity `json:"severity"`
+	Pass     bool         `json:"pass"`
+	Reason   string       `json:"reason,omitempty"`
+}
+
+// RuleFactory builds a rule from config. It returns nil when the config
+// turns the rule off (e.g. a threshold of 0).
+type RuleFactory func(config *models.Config) Rule
+
+var (
+	ruleRegistryMu sync.RWMutex
+	ruleRegistry   = make(map[string]ruleRegistration)
+)
+
+type ruleRegistration struct {
+	factory RuleFactory
+	order   int
+}
+
+// RegisterRule adds a rule to the registry under name, so it can be enabled,
+// disabled and ordered from config. Rules run in registration order unless
+// rules.checks says otherwise. Registering a name again replaces the rule.
+func RegisterRule(name string, factory RuleFactory) {
+	ruleRegistryMu.Lock()
+	defer ruleRegistryMu.Unlock()
+
+	order := len(ruleRegistry)
+	if existing, ok := ruleRegistry[name]; ok {
+		order = existing.order
+	}
+	ruleRegistry[name] = ruleRegistration{factory: factory, order: order}
+}
+
+// RegisteredRules lists the registered rule names in default order
+func RegisteredRules() []string {
+	ruleRegistryMu.RLock()
+	defer ruleRegistryMu.RUnlock()
+
+	names := make([]string, 0, len(ruleRegistry))
+	for name := range ruleRegistry {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return ruleRegistry[names[i]].order < ruleRegistry[names[j]].order
+	})
+	return names
+}
+
+// buildRules instantiates the active rules in the configured order:
+// rules.checks (if set) picks and orders them, rules.disabled removes some.
+// Expression rules run after the registered ones unless rules.checks places
+// them.
+func buildRules(config *models.Config) []Rule {
+	expressions := make(map[string]Rule)
+	var expressionNames []string
+	for _, rule := range compileExpressionRules(config.Rules.Expressions) {
+		expressions[rule.Name()] = rule
+		expressionNames = append(expressionNames, rule.Name())
+	}
+
+	names := config.Rules.Checks
+	if len(names) == 0 {
+		names = append(RegisteredRules(), expressionNames...)
+	}
+
+	disabled := make(map[string]bool, len(config.Rules.Disabled))
+	for _, name := range config.Rules.Disabled {
+		disabled[name] = true
+	}
+
+	ruleRegistryMu.RLock()
+	defer ruleRegistryMu.RUnlock()
+
+	var rules []Rule
+	for _, name := range names {
+		if disabled[name] {
+			continue
+		}
+		registration, ok := ruleRegistry[name]
+		if !ok {
+			if rule, ok := expressions[name]; ok {
+				rules = append(rules, rule)
+				continue
+			}
+			logger.Warn().Str("rule", name).Msg("⚠️  Ignoring unknown rule in rules.checks")
+			continue
+		}
+		if rule := registration.factory(config); rule != nil {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
@@ -0,0 +1,140 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/speier/tokenscout/internal/logger"
+	"github.com/speier/tokenscout/internal/models"
+	"github.com/speier/tokenscout/internal/solana"
+)
+
+// Size is the outcome of a sizing decision
+type Size struct {
+	SOL   float64
+	Quote *solana.QuoteResponse // Quote for exactly SOL, if the sizer already fetched one
+}
+
+// PositionSizer decides how much SOL a buy should spend
+type PositionSizer interface {
+	Size(ctx context.Context, mint string, score float64) (*Size, error)
+}
+
+// NewPositionSizer returns the sizer for the configured sizing mode.
+// Every mode is capped at max_spend_per_trade.
+func NewPositionSizer(config *models.Config, solanaClient *solana.Client, jupiterClient *solana.JupiterClient) PositionSizer {
+	maxSOL := config.Trading.MaxSpendPerTrade
+
+	switch config.Trading.SizingMode {
+	case models.SizingWalletPct:
+		return &walletPctSizer{maxSOL: maxSOL, pct: config.Trading.WalletPct, client: solanaClient}
+	case models.SizingPriceImpact:
+		return &priceImpactSizer{
+			maxSOL:      maxSOL,
+			minSOL:      config.Trading.MinSpendPerTrade,
+			maxImpact:   config.Trading.MaxPriceImpactPct,
+			slippageBps: config.Trading.SlippageBps,
+			jupiter:     jupiterClient,
+		}
+	case models.SizingScore:
+		return &scoreSizer{maxSOL: maxSOL}
+	case models.SizingFixed, "":
+		return &fixedSizer{maxSOL: maxSOL}
+	default:
+		logger.Warn().
+			Str("sizing_mode", string(config.Trading.SizingMode)).
+			Msg("Unknown sizing mode, using fixed")
+		return &fixedSizer{maxSOL: maxSOL}
+	}
+}
+
+// fixedSizer always spends max_spend_per_trade
+type fixedSizer struct {
+	maxSOL float64
+}
+
+func (s *fixedSizer) Size(ctx context.Context, mint string, score float64) (*Size, error) {
+	return &Size{SOL: s.maxSOL}, nil
+}
+
+// walletPctSizer spends a fixed share of the current wallet balance
+type walletPctSizer struct {
+	maxSOL float64
+	pct    float64
+	client *solana.Client
+}
+
+func (s *walletPctSizer) Size(ctx context.Context, mint string, score float64) (*Size, error) {
+	if s.client == nil || s.client.GetWallet() == nil {
+		// Dry-run without a wallet has no bankroll to size against
+		logger.Debug().Msg("No wallet loaded, wallet_pct sizing falls back to max_spend_per_trade")
+		return &Size{SOL: s.maxSOL}, nil
+	}
+
+	lamports, err := s.client.GetBalance(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet balance: %w", err)
+	}
+
+	balance := solana.ConvertLamportsToSOL(lamports)
+	return &Size{SOL: math.Min(balance*s.pct/100, s.maxSOL)}, nil
+}
+
+// priceImpactSizer shrinks the buy until the quoted price impact is under the cap.
+// When no quoted size passes, it sizes the buy at zero so the executor skips it.
+type priceImpactSizer struct {
+	maxSOL      float64
+	minSOL      float64
+	maxImpact   float64
+	slippageBps int
+	jupiter     *solana.JupiterClient
+}
+
+const priceImpactSizingAttempts = 4
+
+func (s *priceImpactSizer) Size(ctx context.Context, mint string, score float64) (*Size, error) {
+	size := s.maxSOL
+
+	for i := 0; i < priceImpactSizingAttempts; i++ {
+		quote, err := s.jupiter.GetQuote(ctx, buyQuoteRequest(mint, size, s.slippageBps))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get quote: %w", err)
+		}
+
+		// Jupiter reports impact as a fraction
+		impact := parsePriceImpact(quote.PriceImpactPct) * 100
+		if s.maxImpact <= 0 || impact <= s.maxImpact {
+			return &Size{SOL: size, Quote: quote}, nil
+		}
+
+		logger.Debug().
+			Str("mint", formatMint(mint)).
+			Float64("size_sol", size).
+			Float64("impact_pct", impact).
+			Msg("Price impact above cap, shrinking buy")
+
+		// Impact grows roughly linearly with size in a constant-product pool;
+		// aim a little under the cap
+		size = size * s.maxImpact / impact * 0.9
+		if size < s.minSOL {
+			break
+		}
+	}
+
+	// Never hand back a size whose impact wasn't quoted under the cap
+	logger.Debug().
+		Str("mint", formatMint(mint)).
+		Float64("max_impact_pct", s.maxImpact).
+		Msg("No buy size within the price impact cap")
+	return &Size{SOL: 0}, nil
+}
+
+// scoreSizer scales max_spend_per_trade by the rule-engine conviction score
+type scoreSizer struct {
+	maxSOL float64
+}
+
+func (s *scoreSizer) Size(ctx context.Context, mint string, score float64) (*Size, error) {
+	return &Size{SOL: s.maxSOL * math.Max(0, math.Min(1, score))}, nil
+}
@@ -0,0 +1,149 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/speier/tokenscout/internal/models"
+	"github.com/speier/tokenscout/internal/solana"
+)
+
+func TestNewPositionSizer(t *testing.T) {
+	tests := []struct {
+		mode models.SizingMode
+		want PositionSizer
+	}{
+		{"", &fixedSizer{}},
+		{models.SizingFixed, &fixedSizer{}},
+		{models.SizingWalletPct, &walletPctSizer{}},
+		{models.SizingPriceImpact, &priceImpactSizer{}},
+		{models.SizingScore, &scoreSizer{}},
+		{"martingale", &fixedSizer{}},
+	}
+
+	for _, tt := range tests {
+		config := &models.Config{Trading: models.TradingConfig{SizingMode: tt.mode, MaxSpendPerTrade: 0.5}}
+		got := NewPositionSizer(config, nil, nil)
+		if gotType, wantType := typeName(got), typeName(tt.want); gotType != wantType {
+			t.Errorf("NewPositionSizer(%q) = %s, want %s", tt.mode, gotType, wantType)
+		}
+	}
+}
+
+func typeName(v interface{}) string {
+	switch v.(type) {
+	case *fixedSizer:
+		return "fixed"
+	case *walletPctSizer:
+		return "wallet_pct"
+	case *priceImpactSizer:
+		return "price_impact"
+	case *scoreSizer:
+		return "score"
+	}
+	return "unknown"
+}
+
+func TestScoreSizer(t *testing.T) {
+	sizer := &scoreSizer{maxSOL: 2}
+	tests := []struct {
+		score float64
+		want  float64
+	}{
+		{1, 2},
+		{0.5, 1},
+		{0, 0},
+		{1.5, 2},  // Scores above 1 are capped
+		{-0.5, 0}, // Negative scores spend nothing
+	}
+
+	for _, tt := range tests {
+		size, err := sizer.Size(context.Background(), "mint", tt.score)
+		if err != nil {
+			t.Fatalf("Size(%v): %v", tt.score, err)
+		}
+		if size.SOL != tt.want {
+			t.Errorf("Size(%v) = %v, want %v", tt.score, size.SOL, tt.want)
+		}
+	}
+}
+
+func TestFixedAndWalletPctSizerWithoutWallet(t *testing.T) {
+	for _, sizer := range []PositionSizer{
+		&fixedSizer{maxSOL: 0.25},
+		&walletPctSizer{maxSOL: 0.25, pct: 10}, // No wallet to size against
+	} {
+		size, err := sizer.Size(context.Background(), "mint", 0.1)
+		if err != nil {
+			t.Fatalf("%s Size: %v", typeName(sizer), err)
+		}
+		if size.SOL != 0.25 {
+			t.Errorf("%s Size = %v, want 0.25", typeName(sizer), size.SOL)
+		}
+	}
+}
+
+// impactServer quotes a price impact of baseImpact percent plus impactPerSOL
+// percent for every SOL bought
+func impactServer(t *testing.T, baseImpact, impactPerSOL float64) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lamports, err := strconv.ParseUint(r.URL.Query().Get("amount"), 10, 64)
+		if err != nil {
+			t.Errorf("bad amount: %v", err)
+		}
+		impact := (baseImpact + solana.ConvertLamportsToSOL(lamports)*impactPerSOL) / 100 // Jupiter reports a fraction
+		json.NewEncoder(w).Encode(solana.QuoteResponse{
+			InAmount:       strconv.FormatUint(lamports, 10),
+			OutAmount:      "1000",
+			PriceImpactPct: strconv.FormatFloat(impact, 'f', -1, 64),
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestPriceImpactSizer(t *testing.T) {
+	tests := []struct {
+		name         string
+		baseImpact   float64
+		impactPerSOL float64
+		maxImpact    float64
+		minSOL       float64
+		want         float64
+		wantQuote    bool
+	}{
+		{"under the cap spends the max", 0, 0.5, 1, 0, 1, true},
+		{"over the cap shrinks the buy", 0, 2, 1, 0, 0.45, true},
+		{"no cap spends the max", 0, 50, 0, 0, 1, true},
+		{"shrinking below the minimum skips", 0, 20, 1, 0.1, 0, false},
+		{"over the cap at every size skips", 1.5, 2, 1, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := impactServer(t, tt.baseImpact, tt.impactPerSOL)
+			sizer := &priceImpactSizer{
+				maxSOL:    1,
+				minSOL:    tt.minSOL,
+				maxImpact: tt.maxImpact,
+				jupiter:   solana.NewJupiterClient(server.URL),
+			}
+
+			size, err := sizer.Size(context.Background(), "mint", 1)
+			if err != nil {
+				t.Fatalf("Size: %v", err)
+			}
+			if math.Abs(size.SOL-tt.want) > 1e-9 {
+				t.Errorf("Size = %v SOL, want %v", size.SOL, tt.want)
+			}
+			if (size.Quote != nil) != tt.wantQuote {
+				t.Errorf("Size quote = %v, want quote: %v", size.Quote, tt.wantQuote)
+			}
+		})
+	}
+}
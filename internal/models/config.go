@@ -7,6 +7,16 @@ const (
 	ModeDryRun Mode = "dry_run"
 )
 
+// SizingMode selects how much SOL each buy spends
+type SizingMode string
+
+const (
+	SizingFixed       SizingMode = "fixed"        // Always max_spend_per_trade
+	SizingWalletPct   SizingMode = "wallet_pct"   // A percentage of the current wallet balance
+	SizingPriceImpact SizingMode = "price_impact" // Largest size whose quoted price impact stays under the cap
+	SizingScore       SizingMode = "score"        // max_spend_per_trade scaled by the rule-engine score
+)
+
 type Config struct {
 	Engine   EngineConfig   `yaml:"engine"`
 	Solana   SolanaConfig   `yaml:"solana"`
@@ -29,15 +39,54 @@ type TradingConfig struct {
 	MaxOpenPositions         int     `yaml:"max_open_positions" mapstructure:"max_open_positions"`
 	SlippageBps              int     `yaml:"slippage_bps" mapstructure:"slippage_bps"`
 	PriorityFeeMicroLamports int64   `yaml:"priority_fee_microlamports" mapstructure:"priority_fee_microlamports"`
+
+	// Position sizing; max_spend_per_trade is the upper bound in every mode
+	SizingMode        SizingMode `yaml:"sizing_mode" mapstructure:"sizing_mode"`
+	WalletPct         float64    `yaml:"wallet_pct" mapstructure:"wallet_pct"`                     // wallet_pct mode: % of wallet balance per trade
+	MaxPriceImpactPct float64    `yaml:"max_price_impact_pct" mapstructure:"max_price_impact_pct"` // price_impact mode: max quoted impact
+	MinSpendPerTrade  float64    `yaml:"min_spend_per_trade" mapstructure:"min_spend_per_trade"`   // Skip buys sized below this (SOL)
 }
 
 type RulesConfig struct {
-	MinLiquidityUSD      float64 `yaml:"min_liquidity_usd" mapstructure:"min_liquidity_usd"`
-	MaxMintAgeSec        int     `yaml:"max_mint_age_sec" mapstructure:"max_mint_age_sec"`
-	MinHolders           int     `yaml:"min_holders" mapstructure:"min_holders"`
-	DevWalletMaxPct      float64 `yaml:"dev_wallet_max_pct" mapstructure:"dev_wallet_max_pct"`
-	BlockFreezeAuthority bool    `yaml:"block_freeze_authority" mapstructure:"block_freeze_authority"`
-	AllowMintAuthority   bool    `yaml:"allow_mint_authority" mapstructure:"allow_mint_authority"`
+	Checks     []string `yaml:"checks" mapstructure:"checks"`           // Rules to run, in order (empty = every registered rule)
+	Disabled   []string `yaml:"disabled" mapstructure:"disabled"`       // Rules to skip
+	FullReport bool     `yaml:"full_report" mapstructure:"full_report"` // Run every rule and list all failures instead of stopping at the first
+
+	// Custom entry filters written as boolean expressions over token facts
+	Expressions []RuleExpression `yaml:"expressions" mapstructure:"expressions"`
+
+	MinLiquidityUSD      float64  `yaml:"min_liquidity_usd" mapstructure:"min_liquidity_usd"`
+	MaxMintAgeSec        int      `yaml:"max_mint_age_sec" mapstructure:"max_mint_age_sec"`
+	MinHolders           int      `yaml:"min_holders" mapstructure:"min_holders"`
+	DevWalletMaxPct      float64  `yaml:"dev_wallet_max_pct" mapstructure:"dev_wallet_max_pct"`
+	MinLPBurnedPct       float64  `yaml:"min_lp_burned_pct" mapstructure:"min_lp_burned_pct"`             // Share of LP that must be burned or locked (0 = off)
+	MaxRoundTripLossPct  float64  `yaml:"max_round_trip_loss_pct" mapstructure:"max_round_trip_loss_pct"` // Max SOL lost quoting a buy and immediate sell (0 = only require a sell route)
+	BlockFreezeAuthority bool     `yaml:"block_freeze_authority" mapstructure:"block_freeze_authority"`
+	AllowMintAuthority   bool     `yaml:"allow_mint_authority" mapstructure:"allow_mint_authority"`
+	BlockMutableMetadata bool     `yaml:"block_mutable_metadata" mapstructure:"block_mutable_metadata"` // Reject tokens whose metadata can still be edited
+	BlockUpdateAuthority bool     `yaml:"block_update_authority" mapstructure:"block_update_authority"` // Reject tokens with a live metadata update authority
+	NameBlocklist        []string `yaml:"name_blocklist" mapstructure:"name_blocklist"`                 // Regexes matched (case-insensitive) against name and symbol
+
+	// Sybil holders: wallets funded by one source around launch count as one holder
+	HolderClusterWindowSlots int `yaml:"holder_cluster_window_slots" mapstructure:"holder_cluster_window_slots"` // Slots around launch a wallet's first transaction must land in to be traced (0 = off)
+
+	// Deployer reputation
+	BlockBlacklistedDeployers bool `yaml:"block_blacklisted_deployers" mapstructure:"block_blacklisted_deployers"` // Record each mint's creator and funder, reject blacklisted ones
+	DeployerFastStopSec       int  `yaml:"deployer_fast_stop_sec" mapstructure:"deployer_fast_stop_sec"`           // A stop-loss this soon after entry blacklists the deployer (0 = only rug exits do)
+
+	// Token-2022 extensions
+	MaxTransferFeePct      float64 `yaml:"max_transfer_fee_pct" mapstructure:"max_transfer_fee_pct"`         // Reject transfer fees above this (0 = no fee allowed)
+	BlockTransferHook      bool    `yaml:"block_transfer_hook" mapstructure:"block_transfer_hook"`           // Reject tokens that run a program on every transfer
+	BlockPermanentDelegate bool    `yaml:"block_permanent_delegate" mapstructure:"block_permanent_delegate"` // Reject tokens someone can move out of any wallet
+	BlockDefaultFrozen     bool    `yaml:"block_default_frozen" mapstructure:"block_default_frozen"`         // Reject tokens whose accounts start frozen
+	BlockNonTransferable   bool    `yaml:"block_non_transferable" mapstructure:"block_non_transferable"`     // Reject soulbound tokens (can't be sold)
+}
+
+// RuleExpression is a custom rule, e.g. "holders >= 20 && top10_pct < 60"
+type RuleExpression struct {
+	Name     string `yaml:"name" mapstructure:"name"`         // Rule name in logs, rules.checks and rules.disabled
+	Expr     string `yaml:"expr" mapstructure:"expr"`         // Must be true for the token to pass
+	Severity string `yaml:"severity" mapstructure:"severity"` // critical (default), watch or warn
 }
 
 type SolanaConfig struct {
@@ -57,10 +106,34 @@ type ListenerConfig struct {
 	WebhookPort      int      `yaml:"webhook_port" mapstructure:"webhook_port"`     // Port for webhook server
 	WebhookPath      string   `yaml:"webhook_path" mapstructure:"webhook_path"`     // Path for webhook endpoint
 	WebhookSecret    string   `yaml:"webhook_secret" mapstructure:"webhook_secret"` // Optional: verify webhook requests
+	PriceStream      bool     `yaml:"price_stream" mapstructure:"price_stream"`     // Stream held tokens' pool reserves over WebSocket
 }
 
 type RiskConfig struct {
 	StopLossPct         float64 `yaml:"stop_loss_pct" mapstructure:"stop_loss_pct"`
 	TakeProfitPct       float64 `yaml:"take_profit_pct" mapstructure:"take_profit_pct"`
 	MaxTradeDurationSec int     `yaml:"max_trade_duration_sec" mapstructure:"max_trade_duration_sec"`
+	TrailingStopPct     float64 `yaml:"trailing_stop_pct" mapstructure:"trailing_stop_pct"`         // Exit when price drops this far below the high-water mark (0 = off)
+	BreakevenTriggerPct float64 `yaml:"breakeven_trigger_pct" mapstructure:"breakeven_trigger_pct"` // Once up this much, exit if price falls back to entry (0 = off)
+
+	// Scale-out ladder; when set it replaces the single take_profit_pct exit
+	TakeProfitLadder []TakeProfitStep `yaml:"take_profit_ladder" mapstructure:"take_profit_ladder"`
+
+	// Portfolio circuit breakers; they pause new entries but never block exits (0 = off)
+	MaxDailyLossUSD      float64 `yaml:"max_daily_loss_usd" mapstructure:"max_daily_loss_usd"`         // Realized today + unrealized on open positions
+	MaxExposureSOL       float64 `yaml:"max_exposure_sol" mapstructure:"max_exposure_sol"`             // Total SOL deployed across open positions
+	MaxConsecutiveLosses int     `yaml:"max_consecutive_losses" mapstructure:"max_consecutive_losses"` // Losing closes in a row before cooling down
+	LossCooldownSec      int     `yaml:"loss_cooldown_sec" mapstructure:"loss_cooldown_sec"`           // How long to pause after a loss streak
+
+	// Emergency exits on rug signals for held tokens
+	RugCheckIntervalSec      int     `yaml:"rug_check_interval_sec" mapstructure:"rug_check_interval_sec"`             // How often to run the (RPC-heavy) checks per position (0 = off)
+	RugLiquidityDropPct      float64 `yaml:"rug_liquidity_drop_pct" mapstructure:"rug_liquidity_drop_pct"`             // Exit when pool depth falls this far below its peak (0 = off)
+	RugHolderDumpPct         float64 `yaml:"rug_holder_dump_pct" mapstructure:"rug_holder_dump_pct"`                   // Exit when the top holder sells this share of their bag (0 = off)
+	RugExitOnAuthorityChange bool    `yaml:"rug_exit_on_authority_change" mapstructure:"rug_exit_on_authority_change"` // Exit when mint/freeze authority appears or changes
+}
+
+// TakeProfitStep sells part of a position once it reaches a gain
+type TakeProfitStep struct {
+	GainPct float64 `yaml:"gain_pct" mapstructure:"gain_pct"` // Gain vs entry price that triggers the step
+	SellPct float64 `yaml:"sell_pct" mapstructure:"sell_pct"` // Share of the original position to sell
 }
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// MintDeployer records who launched a mint
+type MintDeployer struct {
+	Mint       string    `json:"mint"`
+	Creator    string    `json:"creator"` // Wallet that paid for the mint's first transaction
+	Funder     string    `json:"funder"`  // Wallet that first funded the creator, "" if unknown
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+// DeployerStats links a deployer wallet to our trade outcomes on its tokens
+type DeployerStats struct {
+	Wallet          string    `json:"wallet"`
+	Mints           int       `json:"mints"`  // Mints seen from this creator
+	Closed          int       `json:"closed"` // Positions we closed on its tokens
+	Losses          int       `json:"losses"`
+	PnLUSD          float64   `json:"pnl_usd"`
+	Blacklisted     bool      `json:"blacklisted"`
+	BlacklistReason string    `json:"blacklist_reason,omitempty"` // Exit reason that triggered it, or "manual"
+	BlacklistMint   string    `json:"blacklist_mint,omitempty"`   // Token that got the deployer blacklisted
+	BlacklistedAt   time.Time `json:"blacklisted_at,omitempty"`
+}
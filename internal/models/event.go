@@ -8,6 +8,7 @@ const (
 	EventTypeNewMint EventType = "NEW_MINT"
 	EventTypeNewPool EventType = "NEW_POOL"
 	EventTypeLPAdd   EventType = "LP_ADD"
+	EventTypeManual  EventType = "MANUAL" // Entry requested by hand from the CLI
 )
 
 type Event struct {
@@ -15,7 +16,8 @@ type Event struct {
 	Type      EventType `json:"type"`
 	Mint      string    `json:"mint"`
 	Pair      string    `json:"pair"`
-	LPAddress string    `json:"lp_address"`
+	LPAddress string    `json:"lp_address"` // Pool account, when the listener parsed the pool init
+	LPMint    string    `json:"lp_mint"`    // Pool's LP token mint (Raydium only)
 	Timestamp time.Time `json:"timestamp"`
 	Raw       string    `json:"raw"`
 }
@@ -4,8 +4,20 @@ import "time"
 
 type Position struct {
 	Mint         string    `json:"mint"`
+	Symbol       string    `json:"symbol"`
 	Quantity     string    `json:"quantity"`
+	RawAmount    string    `json:"raw_amount"`  // Exact token amount in base units (u64)
+	InitialRaw   string    `json:"initial_raw"` // Raw amount when opened, before any partial sells
+	Decimals     uint8     `json:"decimals"`
 	AvgPriceUSD  float64   `json:"avg_price_usd"`
+	HighWaterUSD float64   `json:"high_water_usd"` // Highest price seen while open
+	TPStepsHit   int       `json:"tp_steps_hit"`   // Take-profit ladder steps already sold
+	CostSOL      float64   `json:"cost_sol"`       // SOL spent on the entry swap
+	CostUSD      float64   `json:"cost_usd"`
+	ProceedsSOL  float64   `json:"proceeds_sol"` // SOL received from partial sells so far
+	ProceedsUSD  float64   `json:"proceeds_usd"`
+	FeesSOL      float64   `json:"fees_sol"`     // Network fees paid so far
+	PoolAddress  string    `json:"pool_address"` // AMM pool the entry was routed through, used for live price updates
 	OpenedAt     time.Time `json:"opened_at"`
 	LastUpdateAt time.Time `json:"last_update_at"`
 	Strategy     string    `json:"strategy"` // Strategy name used for this position
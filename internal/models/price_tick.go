@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// PriceTick is one price observation for an open position
+type PriceTick struct {
+	ID        int64     `json:"id"`
+	Mint      string    `json:"mint"`
+	Timestamp time.Time `json:"timestamp"`
+	PriceUSD  float64   `json:"price_usd"`
+	Source    string    `json:"source"` // "stream" or the price service source
+}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// RealizedPnL is the ledger entry written when a position is fully closed
+type RealizedPnL struct {
+	ID              int64     `json:"id"`
+	Mint            string    `json:"mint"`
+	Strategy        string    `json:"strategy"`
+	OpenedAt        time.Time `json:"opened_at"`
+	ClosedAt        time.Time `json:"closed_at"`
+	HoldSec         int64     `json:"hold_sec"`
+	EntryCostSOL    float64   `json:"entry_cost_sol"`
+	EntryCostUSD    float64   `json:"entry_cost_usd"`
+	ExitProceedsSOL float64   `json:"exit_proceeds_sol"` // Sum over all sell tranches
+	ExitProceedsUSD float64   `json:"exit_proceeds_usd"`
+	FeesSOL         float64   `json:"fees_sol"` // Network fees paid on buy and sells
+	FeesUSD         float64   `json:"fees_usd"`
+	PnLSOL          float64   `json:"pnl_sol"`
+	PnLUSD          float64   `json:"pnl_usd"`
+	ExitReason      string    `json:"exit_reason"`      // Reason of the sell that closed the position
+	MFEPct          float64   `json:"mfe_pct"`          // Maximum favorable excursion: best price seen vs entry
+	MAEPct          float64   `json:"mae_pct"`          // Maximum adverse excursion: worst price seen vs entry
+	TimeToPeakSec   int64     `json:"time_to_peak_sec"` // From open to the best price seen
+}
+
+// PnLSummary aggregates realized PnL over a period
+type PnLSummary struct {
+	Closed int     `json:"closed"`
+	Wins   int     `json:"wins"`
+	PnLSOL float64 `json:"pnl_sol"`
+	PnLUSD float64 `json:"pnl_usd"`
+}
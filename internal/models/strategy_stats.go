@@ -12,4 +12,9 @@ type StrategyStats struct {
 	ExecutedTrades int     `json:"executed_trades"`
 	FailedTrades   int     `json:"failed_trades"`
 	SuccessRate    float64 `json:"success_rate_pct"`
+	ClosedTrades   int     `json:"closed_positions"`
+	WinRate        float64 `json:"win_rate_pct"`
+	RealizedPnLUSD float64 `json:"realized_pnl_usd"`
+	AvgMFEPct      float64 `json:"avg_mfe_pct"` // Average best excursion of closed positions
+	AvgMAEPct      float64 `json:"avg_mae_pct"` // Average worst excursion of closed positions
 }
@@ -22,9 +22,13 @@ type Trade struct {
 	Timestamp time.Time   `json:"timestamp"`
 	Side      TradeSide   `json:"side"`
 	Mint      string      `json:"mint"`
+	Symbol    string      `json:"symbol"` // Token symbol from its metadata, "" if unknown
 	Quantity  string      `json:"quantity"`
+	RawAmount string      `json:"raw_amount"` // Exact token amount in base units (u64)
 	PriceUSD  float64     `json:"price_usd"`
+	PnLUSD    float64     `json:"pnl_usd"` // Realized PnL of a sell against the position's entry price
 	TxSig     string      `json:"tx_sig"`
 	Status    TradeStatus `json:"status"`
 	Strategy  string      `json:"strategy"` // Strategy name used for this trade
+	Reason    string      `json:"reason"`   // Why the trade was made (e.g. rules_passed, stop_loss)
 }
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/speier/tokenscout/internal/models"
 )
@@ -11,7 +12,9 @@ type Repository interface {
 	CreateTrade(ctx context.Context, trade *models.Trade) error
 	GetTrades(ctx context.Context, limit int) ([]models.Trade, error)
 	GetTradeByID(ctx context.Context, id int64) (*models.Trade, error)
+	GetPendingTrades(ctx context.Context, before time.Time) ([]models.Trade, error)
 	UpdateTradeStatus(ctx context.Context, id int64, status models.TradeStatus, txSig string) error
+	UpdateTradeFill(ctx context.Context, id int64, quantity, rawAmount string, priceUSD, pnlUSD float64) error
 
 	// Positions
 	CreatePosition(ctx context.Context, position *models.Position) error
@@ -20,6 +23,15 @@ type Repository interface {
 	UpdatePosition(ctx context.Context, position *models.Position) error
 	DeletePosition(ctx context.Context, mint string) error
 
+	// Realized PnL ledger
+	CreateRealizedPnL(ctx context.Context, entry *models.RealizedPnL) error
+	GetPnLSummary(ctx context.Context, since time.Time) (*models.PnLSummary, error)
+	GetRecentRealizedPnL(ctx context.Context, limit int) ([]models.RealizedPnL, error)
+
+	// Price history
+	CreatePriceTick(ctx context.Context, tick *models.PriceTick) error
+	GetPriceTicks(ctx context.Context, mint string, since time.Time) ([]models.PriceTick, error)
+
 	// Events
 	CreateEvent(ctx context.Context, event *models.Event) error
 	GetRecentEvents(ctx context.Context, limit int) ([]models.Event, error)
@@ -34,6 +46,14 @@ type Repository interface {
 	AddToBlacklist(ctx context.Context, mint string) error
 	AddToWhitelist(ctx context.Context, mint string) error
 
+	// Deployers
+	SaveMintDeployer(ctx context.Context, deployer *models.MintDeployer) error
+	GetMintDeployer(ctx context.Context, mint string) (*models.MintDeployer, error)
+	IsDeployerBlacklisted(ctx context.Context, wallet string) (bool, error)
+	BlacklistDeployer(ctx context.Context, wallet, mint, reason string) error
+	RemoveDeployerFromBlacklist(ctx context.Context, wallet string) error
+	GetDeployerStats(ctx context.Context, limit int) ([]models.DeployerStats, error)
+
 	// Strategy Analytics
 	GetStrategyStats(ctx context.Context) ([]models.StrategyStats, error)
 
@@ -40,28 +40,74 @@ func (r *SQLiteRepository) runMigrations() error {
 		timestamp INTEGER NOT NULL,
 		side TEXT NOT NULL,
 		mint TEXT NOT NULL,
+		symbol TEXT DEFAULT '',
 		quantity TEXT NOT NULL,
+		raw_amount TEXT DEFAULT '',
 		price_usd REAL,
+		pnl_usd REAL DEFAULT 0,
 		tx_sig TEXT,
 		status TEXT NOT NULL,
-		strategy TEXT DEFAULT ''
+		strategy TEXT DEFAULT '',
+		reason TEXT DEFAULT ''
 	);
 
 	CREATE TABLE IF NOT EXISTS positions (
 		mint TEXT PRIMARY KEY,
+		symbol TEXT DEFAULT '',
 		quantity TEXT NOT NULL,
+		raw_amount TEXT DEFAULT '',
+		initial_raw TEXT DEFAULT '',
+		decimals INTEGER DEFAULT 9,
 		avg_price_usd REAL,
+		high_water_usd REAL DEFAULT 0,
+		tp_steps_hit INTEGER DEFAULT 0,
+		cost_sol REAL DEFAULT 0,
+		cost_usd REAL DEFAULT 0,
+		proceeds_sol REAL DEFAULT 0,
+		proceeds_usd REAL DEFAULT 0,
+		fees_sol REAL DEFAULT 0,
+		pool_address TEXT DEFAULT '',
 		opened_at INTEGER NOT NULL,
 		last_update_at INTEGER NOT NULL,
 		strategy TEXT DEFAULT ''
 	);
 
+	CREATE TABLE IF NOT EXISTS realized_pnl (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		mint TEXT NOT NULL,
+		strategy TEXT DEFAULT '',
+		opened_at INTEGER NOT NULL,
+		closed_at INTEGER NOT NULL,
+		hold_sec INTEGER NOT NULL,
+		entry_cost_sol REAL,
+		entry_cost_usd REAL,
+		exit_proceeds_sol REAL,
+		exit_proceeds_usd REAL,
+		fees_sol REAL,
+		fees_usd REAL,
+		pnl_sol REAL,
+		pnl_usd REAL,
+		exit_reason TEXT DEFAULT '',
+		mfe_pct REAL DEFAULT 0,
+		mae_pct REAL DEFAULT 0,
+		time_to_peak_sec INTEGER DEFAULT 0
+	);
+
+	CREATE TABLE IF NOT EXISTS price_ticks (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		mint TEXT NOT NULL,
+		timestamp INTEGER NOT NULL,
+		price_usd REAL NOT NULL,
+		source TEXT DEFAULT ''
+	);
+
 	CREATE TABLE IF NOT EXISTS events (
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
 		type TEXT NOT NULL,
 		mint TEXT NOT NULL,
 		pair TEXT,
 		lp_address TEXT,
+		lp_mint TEXT DEFAULT '',
 		timestamp INTEGER NOT NULL,
 		raw TEXT
 	);
@@ -79,9 +125,26 @@ func (r *SQLiteRepository) runMigrations() error {
 		mint TEXT PRIMARY KEY
 	);
 
+	CREATE TABLE IF NOT EXISTS mint_deployers (
+		mint TEXT PRIMARY KEY,
+		creator TEXT NOT NULL,
+		funder TEXT DEFAULT '',
+		detected_at INTEGER NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS deployer_blacklist (
+		wallet TEXT PRIMARY KEY,
+		mint TEXT DEFAULT '',
+		reason TEXT DEFAULT '',
+		added_at INTEGER NOT NULL
+	);
+
 	CREATE INDEX IF NOT EXISTS idx_trades_timestamp ON trades(timestamp);
 	CREATE INDEX IF NOT EXISTS idx_events_timestamp ON events(timestamp);
+	CREATE INDEX IF NOT EXISTS idx_realized_pnl_closed_at ON realized_pnl(closed_at);
 	CREATE INDEX IF NOT EXISTS idx_events_type ON events(type);
+	CREATE INDEX IF NOT EXISTS idx_price_ticks_mint_timestamp ON price_ticks(mint, timestamp);
+	CREATE INDEX IF NOT EXISTS idx_mint_deployers_creator ON mint_deployers(creator);
 	`
 
 	if _, err := r.db.Exec(schema); err != nil {
@@ -93,33 +156,57 @@ func (r *SQLiteRepository) runMigrations() error {
 }
 
 func (r *SQLiteRepository) runAdditionalMigrations() error {
-	// Check if strategy column exists in trades table
-	var hasStrategyInTrades int
-	err := r.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('trades') WHERE name='strategy'`).Scan(&hasStrategyInTrades)
-	if err != nil {
-		return fmt.Errorf("failed to check trades table schema: %w", err)
+	// Columns added after the initial schema, in the order they were introduced
+	columns := []struct {
+		table      string
+		column     string
+		definition string
+	}{
+		{"trades", "strategy", "TEXT DEFAULT ''"},
+		{"positions", "strategy", "TEXT DEFAULT ''"},
+		{"trades", "raw_amount", "TEXT DEFAULT ''"},
+		{"positions", "raw_amount", "TEXT DEFAULT ''"},
+		{"positions", "decimals", "INTEGER DEFAULT 9"}, // Legacy rows were stored assuming 9 decimals
+		{"positions", "high_water_usd", "REAL DEFAULT 0"},
+		{"trades", "reason", "TEXT DEFAULT ''"},
+		{"trades", "pnl_usd", "REAL DEFAULT 0"},
+		{"positions", "initial_raw", "TEXT DEFAULT ''"},
+		{"positions", "tp_steps_hit", "INTEGER DEFAULT 0"},
+		{"positions", "cost_sol", "REAL DEFAULT 0"},
+		{"positions", "cost_usd", "REAL DEFAULT 0"},
+		{"positions", "proceeds_sol", "REAL DEFAULT 0"},
+		{"positions", "proceeds_usd", "REAL DEFAULT 0"},
+		{"positions", "fees_sol", "REAL DEFAULT 0"},
+		{"positions", "pool_address", "TEXT DEFAULT ''"},
+		{"trades", "symbol", "TEXT DEFAULT ''"},
+		{"positions", "symbol", "TEXT DEFAULT ''"},
+		{"events", "lp_mint", "TEXT DEFAULT ''"},
+		{"realized_pnl", "mfe_pct", "REAL DEFAULT 0"},
+		{"realized_pnl", "mae_pct", "REAL DEFAULT 0"},
+		{"realized_pnl", "time_to_peak_sec", "INTEGER DEFAULT 0"},
 	}
 
-	// Add strategy column to trades if it doesn't exist
-	if hasStrategyInTrades == 0 {
-		_, err := r.db.Exec(`ALTER TABLE trades ADD COLUMN strategy TEXT DEFAULT '';`)
-		if err != nil {
-			return fmt.Errorf("failed to add strategy column to trades: %w", err)
+	for _, c := range columns {
+		if err := r.addColumnIfMissing(c.table, c.column, c.definition); err != nil {
+			return err
 		}
 	}
 
-	// Check if strategy column exists in positions table
-	var hasStrategyInPositions int
-	err = r.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('positions') WHERE name='strategy'`).Scan(&hasStrategyInPositions)
+	return nil
+}
+
+// addColumnIfMissing adds a column to an existing table unless it is already there
+func (r *SQLiteRepository) addColumnIfMissing(table, column, definition string) error {
+	var exists int
+	err := r.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?`, table, column).Scan(&exists)
 	if err != nil {
-		return fmt.Errorf("failed to check positions table schema: %w", err)
+		return fmt.Errorf("failed to check %s table schema: %w", table, err)
 	}
 
-	// Add strategy column to positions if it doesn't exist
-	if hasStrategyInPositions == 0 {
-		_, err := r.db.Exec(`ALTER TABLE positions ADD COLUMN strategy TEXT DEFAULT '';`)
+	if exists == 0 {
+		_, err := r.db.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s %s;`, table, column, definition))
 		if err != nil {
-			return fmt.Errorf("failed to add strategy column to positions: %w", err)
+			return fmt.Errorf("failed to add %s column to %s: %w", column, table, err)
 		}
 	}
 
@@ -127,17 +214,20 @@ func (r *SQLiteRepository) runAdditionalMigrations() error {
 }
 
 func (r *SQLiteRepository) CreateTrade(ctx context.Context, trade *models.Trade) error {
-	query := `INSERT INTO trades (timestamp, side, mint, quantity, price_usd, tx_sig, status, strategy)
-			  VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	query := `INSERT INTO trades (timestamp, side, mint, symbol, quantity, raw_amount, price_usd, tx_sig, status, strategy, reason)
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 	result, err := r.db.ExecContext(ctx, query,
 		trade.Timestamp.Unix(),
 		trade.Side,
 		trade.Mint,
+		trade.Symbol,
 		trade.Quantity,
+		trade.RawAmount,
 		trade.PriceUSD,
 		trade.TxSig,
 		trade.Status,
 		trade.Strategy,
+		trade.Reason,
 	)
 	if err != nil {
 		return err
@@ -151,7 +241,7 @@ func (r *SQLiteRepository) CreateTrade(ctx context.Context, trade *models.Trade)
 }
 
 func (r *SQLiteRepository) GetTrades(ctx context.Context, limit int) ([]models.Trade, error) {
-	query := `SELECT id, timestamp, side, mint, quantity, price_usd, tx_sig, status, COALESCE(strategy, '') as strategy
+	query := `SELECT id, timestamp, side, mint, COALESCE(symbol, ''), quantity, COALESCE(raw_amount, ''), price_usd, COALESCE(pnl_usd, 0), tx_sig, status, COALESCE(strategy, '') as strategy, COALESCE(reason, '')
 			  FROM trades ORDER BY timestamp DESC LIMIT ?`
 	rows, err := r.db.QueryContext(ctx, query, limit)
 	if err != nil {
@@ -163,7 +253,7 @@ func (r *SQLiteRepository) GetTrades(ctx context.Context, limit int) ([]models.T
 	for rows.Next() {
 		var t models.Trade
 		var ts int64
-		err := rows.Scan(&t.ID, &ts, &t.Side, &t.Mint, &t.Quantity, &t.PriceUSD, &t.TxSig, &t.Status, &t.Strategy)
+		err := rows.Scan(&t.ID, &ts, &t.Side, &t.Mint, &t.Symbol, &t.Quantity, &t.RawAmount, &t.PriceUSD, &t.PnLUSD, &t.TxSig, &t.Status, &t.Strategy, &t.Reason)
 		if err != nil {
 			return nil, err
 		}
@@ -174,12 +264,12 @@ func (r *SQLiteRepository) GetTrades(ctx context.Context, limit int) ([]models.T
 }
 
 func (r *SQLiteRepository) GetTradeByID(ctx context.Context, id int64) (*models.Trade, error) {
-	query := `SELECT id, timestamp, side, mint, quantity, price_usd, tx_sig, status, COALESCE(strategy, '') as strategy
+	query := `SELECT id, timestamp, side, mint, COALESCE(symbol, ''), quantity, COALESCE(raw_amount, ''), price_usd, COALESCE(pnl_usd, 0), tx_sig, status, COALESCE(strategy, '') as strategy, COALESCE(reason, '')
 			  FROM trades WHERE id = ?`
 	var t models.Trade
 	var ts int64
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&t.ID, &ts, &t.Side, &t.Mint, &t.Quantity, &t.PriceUSD, &t.TxSig, &t.Status, &t.Strategy,
+		&t.ID, &ts, &t.Side, &t.Mint, &t.Symbol, &t.Quantity, &t.RawAmount, &t.PriceUSD, &t.PnLUSD, &t.TxSig, &t.Status, &t.Strategy, &t.Reason,
 	)
 	if err != nil {
 		return nil, err
@@ -188,19 +278,65 @@ func (r *SQLiteRepository) GetTradeByID(ctx context.Context, id int64) (*models.
 	return &t, nil
 }
 
+// GetPendingTrades returns trades still PENDING that were created before the
+// given time, oldest first
+func (r *SQLiteRepository) GetPendingTrades(ctx context.Context, before time.Time) ([]models.Trade, error) {
+	query := `SELECT id, timestamp, side, mint, COALESCE(symbol, ''), quantity, COALESCE(raw_amount, ''), price_usd, COALESCE(pnl_usd, 0), COALESCE(tx_sig, ''), status, COALESCE(strategy, '') as strategy, COALESCE(reason, '')
+			  FROM trades WHERE status = ? AND timestamp < ? ORDER BY timestamp ASC`
+	rows, err := r.db.QueryContext(ctx, query, models.TradeStatusPending, before.Unix())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var trades []models.Trade
+	for rows.Next() {
+		var t models.Trade
+		var ts int64
+		err := rows.Scan(&t.ID, &ts, &t.Side, &t.Mint, &t.Symbol, &t.Quantity, &t.RawAmount, &t.PriceUSD, &t.PnLUSD, &t.TxSig, &t.Status, &t.Strategy, &t.Reason)
+		if err != nil {
+			return nil, err
+		}
+		t.Timestamp = time.Unix(ts, 0)
+		trades = append(trades, t)
+	}
+	return trades, rows.Err()
+}
+
 func (r *SQLiteRepository) UpdateTradeStatus(ctx context.Context, id int64, status models.TradeStatus, txSig string) error {
 	query := `UPDATE trades SET status = ?, tx_sig = ? WHERE id = ?`
 	_, err := r.db.ExecContext(ctx, query, status, txSig, id)
 	return err
 }
 
+// UpdateTradeFill records what a trade actually filled at. Quantity is in
+// token units, like raw_amount; a pending buy only holds the SOL it meant to spend.
+func (r *SQLiteRepository) UpdateTradeFill(ctx context.Context, id int64, quantity, rawAmount string, priceUSD, pnlUSD float64) error {
+	query := `UPDATE trades SET quantity = ?, raw_amount = ?, price_usd = ?, pnl_usd = ? WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, quantity, rawAmount, priceUSD, pnlUSD, id)
+	return err
+}
+
 func (r *SQLiteRepository) CreatePosition(ctx context.Context, position *models.Position) error {
-	query := `INSERT INTO positions (mint, quantity, avg_price_usd, opened_at, last_update_at, strategy)
-			  VALUES (?, ?, ?, ?, ?, ?)`
+	query := `INSERT INTO positions (mint, symbol, quantity, raw_amount, initial_raw, decimals, avg_price_usd, high_water_usd, tp_steps_hit,
+			  cost_sol, cost_usd, proceeds_sol, proceeds_usd, fees_sol, pool_address, opened_at, last_update_at, strategy)
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 	_, err := r.db.ExecContext(ctx, query,
 		position.Mint,
+		position.Symbol,
 		position.Quantity,
+		position.RawAmount,
+		position.InitialRaw,
+		position.Decimals,
 		position.AvgPriceUSD,
+		position.HighWaterUSD,
+		position.TPStepsHit,
+		position.CostSOL,
+		position.CostUSD,
+		position.ProceedsSOL,
+		position.ProceedsUSD,
+		position.FeesSOL,
+		position.PoolAddress,
 		position.OpenedAt.Unix(),
 		position.LastUpdateAt.Unix(),
 		position.Strategy,
@@ -209,12 +345,14 @@ func (r *SQLiteRepository) CreatePosition(ctx context.Context, position *models.
 }
 
 func (r *SQLiteRepository) GetPosition(ctx context.Context, mint string) (*models.Position, error) {
-	query := `SELECT mint, quantity, avg_price_usd, opened_at, last_update_at, COALESCE(strategy, '') as strategy
+	query := `SELECT mint, COALESCE(symbol, ''), quantity, COALESCE(raw_amount, ''), COALESCE(initial_raw, ''), COALESCE(decimals, 9), avg_price_usd, COALESCE(high_water_usd, 0), COALESCE(tp_steps_hit, 0),
+			  COALESCE(cost_sol, 0), COALESCE(cost_usd, 0), COALESCE(proceeds_sol, 0), COALESCE(proceeds_usd, 0), COALESCE(fees_sol, 0), COALESCE(pool_address, ''), opened_at, last_update_at, COALESCE(strategy, '') as strategy
 			  FROM positions WHERE mint = ?`
 	var p models.Position
 	var openedAt, lastUpdateAt int64
 	err := r.db.QueryRowContext(ctx, query, mint).Scan(
-		&p.Mint, &p.Quantity, &p.AvgPriceUSD, &openedAt, &lastUpdateAt, &p.Strategy,
+		&p.Mint, &p.Symbol, &p.Quantity, &p.RawAmount, &p.InitialRaw, &p.Decimals, &p.AvgPriceUSD, &p.HighWaterUSD, &p.TPStepsHit,
+		&p.CostSOL, &p.CostUSD, &p.ProceedsSOL, &p.ProceedsUSD, &p.FeesSOL, &p.PoolAddress, &openedAt, &lastUpdateAt, &p.Strategy,
 	)
 	if err != nil {
 		return nil, err
@@ -225,7 +363,8 @@ func (r *SQLiteRepository) GetPosition(ctx context.Context, mint string) (*model
 }
 
 func (r *SQLiteRepository) GetAllPositions(ctx context.Context) ([]models.Position, error) {
-	query := `SELECT mint, quantity, avg_price_usd, opened_at, last_update_at, COALESCE(strategy, '') as strategy FROM positions`
+	query := `SELECT mint, COALESCE(symbol, ''), quantity, COALESCE(raw_amount, ''), COALESCE(initial_raw, ''), COALESCE(decimals, 9), avg_price_usd, COALESCE(high_water_usd, 0), COALESCE(tp_steps_hit, 0),
+			  COALESCE(cost_sol, 0), COALESCE(cost_usd, 0), COALESCE(proceeds_sol, 0), COALESCE(proceeds_usd, 0), COALESCE(fees_sol, 0), COALESCE(pool_address, ''), opened_at, last_update_at, COALESCE(strategy, '') as strategy FROM positions`
 	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
@@ -236,7 +375,8 @@ func (r *SQLiteRepository) GetAllPositions(ctx context.Context) ([]models.Positi
 	for rows.Next() {
 		var p models.Position
 		var openedAt, lastUpdateAt int64
-		err := rows.Scan(&p.Mint, &p.Quantity, &p.AvgPriceUSD, &openedAt, &lastUpdateAt, &p.Strategy)
+		err := rows.Scan(&p.Mint, &p.Symbol, &p.Quantity, &p.RawAmount, &p.InitialRaw, &p.Decimals, &p.AvgPriceUSD, &p.HighWaterUSD, &p.TPStepsHit,
+			&p.CostSOL, &p.CostUSD, &p.ProceedsSOL, &p.ProceedsUSD, &p.FeesSOL, &p.PoolAddress, &openedAt, &lastUpdateAt, &p.Strategy)
 		if err != nil {
 			return nil, err
 		}
@@ -248,11 +388,19 @@ func (r *SQLiteRepository) GetAllPositions(ctx context.Context) ([]models.Positi
 }
 
 func (r *SQLiteRepository) UpdatePosition(ctx context.Context, position *models.Position) error {
-	query := `UPDATE positions SET quantity = ?, avg_price_usd = ?, last_update_at = ?
+	query := `UPDATE positions SET quantity = ?, raw_amount = ?, avg_price_usd = ?, high_water_usd = ?, tp_steps_hit = ?,
+			  proceeds_sol = ?, proceeds_usd = ?, fees_sol = ?, pool_address = ?, last_update_at = ?
 			  WHERE mint = ?`
 	_, err := r.db.ExecContext(ctx, query,
 		position.Quantity,
+		position.RawAmount,
 		position.AvgPriceUSD,
+		position.HighWaterUSD,
+		position.TPStepsHit,
+		position.ProceedsSOL,
+		position.ProceedsUSD,
+		position.FeesSOL,
+		position.PoolAddress,
 		position.LastUpdateAt.Unix(),
 		position.Mint,
 	)
@@ -265,14 +413,135 @@ func (r *SQLiteRepository) DeletePosition(ctx context.Context, mint string) erro
 	return err
 }
 
+func (r *SQLiteRepository) CreateRealizedPnL(ctx context.Context, entry *models.RealizedPnL) error {
+	query := `INSERT INTO realized_pnl (mint, strategy, opened_at, closed_at, hold_sec,
+			  entry_cost_sol, entry_cost_usd, exit_proceeds_sol, exit_proceeds_usd, fees_sol, fees_usd, pnl_sol, pnl_usd, exit_reason,
+			  mfe_pct, mae_pct, time_to_peak_sec)
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	result, err := r.db.ExecContext(ctx, query,
+		entry.Mint,
+		entry.Strategy,
+		entry.OpenedAt.Unix(),
+		entry.ClosedAt.Unix(),
+		entry.HoldSec,
+		entry.EntryCostSOL,
+		entry.EntryCostUSD,
+		entry.ExitProceedsSOL,
+		entry.ExitProceedsUSD,
+		entry.FeesSOL,
+		entry.FeesUSD,
+		entry.PnLSOL,
+		entry.PnLUSD,
+		entry.ExitReason,
+		entry.MFEPct,
+		entry.MAEPct,
+		entry.TimeToPeakSec,
+	)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	entry.ID = id
+	return nil
+}
+
+func (r *SQLiteRepository) GetPnLSummary(ctx context.Context, since time.Time) (*models.PnLSummary, error) {
+	query := `SELECT COUNT(*),
+			  COALESCE(SUM(CASE WHEN pnl_usd > 0 THEN 1 ELSE 0 END), 0),
+			  COALESCE(SUM(pnl_sol), 0),
+			  COALESCE(SUM(pnl_usd), 0)
+			  FROM realized_pnl WHERE closed_at >= ?`
+	var s models.PnLSummary
+	err := r.db.QueryRowContext(ctx, query, since.Unix()).Scan(&s.Closed, &s.Wins, &s.PnLSOL, &s.PnLUSD)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *SQLiteRepository) GetRecentRealizedPnL(ctx context.Context, limit int) ([]models.RealizedPnL, error) {
+	query := `SELECT id, mint, COALESCE(strategy, ''), opened_at, closed_at, hold_sec,
+			  COALESCE(entry_cost_sol, 0), COALESCE(entry_cost_usd, 0), COALESCE(exit_proceeds_sol, 0), COALESCE(exit_proceeds_usd, 0),
+			  COALESCE(fees_sol, 0), COALESCE(fees_usd, 0), COALESCE(pnl_sol, 0), COALESCE(pnl_usd, 0), COALESCE(exit_reason, ''),
+			  COALESCE(mfe_pct, 0), COALESCE(mae_pct, 0), COALESCE(time_to_peak_sec, 0)
+			  FROM realized_pnl ORDER BY closed_at DESC, id DESC LIMIT ?`
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []models.RealizedPnL
+	for rows.Next() {
+		var e models.RealizedPnL
+		var openedAt, closedAt int64
+		err := rows.Scan(&e.ID, &e.Mint, &e.Strategy, &openedAt, &closedAt, &e.HoldSec,
+			&e.EntryCostSOL, &e.EntryCostUSD, &e.ExitProceedsSOL, &e.ExitProceedsUSD, &e.FeesSOL, &e.FeesUSD, &e.PnLSOL, &e.PnLUSD, &e.ExitReason,
+			&e.MFEPct, &e.MAEPct, &e.TimeToPeakSec)
+		if err != nil {
+			return nil, err
+		}
+		e.OpenedAt = time.Unix(openedAt, 0)
+		e.ClosedAt = time.Unix(closedAt, 0)
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+func (r *SQLiteRepository) CreatePriceTick(ctx context.Context, tick *models.PriceTick) error {
+	query := `INSERT INTO price_ticks (mint, timestamp, price_usd, source) VALUES (?, ?, ?, ?)`
+	result, err := r.db.ExecContext(ctx, query,
+		tick.Mint,
+		tick.Timestamp.UnixMilli(),
+		tick.PriceUSD,
+		tick.Source,
+	)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	tick.ID = id
+	return nil
+}
+
+// GetPriceTicks returns a mint's price observations since the given time, oldest first
+func (r *SQLiteRepository) GetPriceTicks(ctx context.Context, mint string, since time.Time) ([]models.PriceTick, error) {
+	query := `SELECT id, mint, timestamp, price_usd, COALESCE(source, '')
+			  FROM price_ticks WHERE mint = ? AND timestamp >= ? ORDER BY timestamp ASC, id ASC`
+	rows, err := r.db.QueryContext(ctx, query, mint, since.UnixMilli())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ticks []models.PriceTick
+	for rows.Next() {
+		var t models.PriceTick
+		var ts int64
+		if err := rows.Scan(&t.ID, &t.Mint, &ts, &t.PriceUSD, &t.Source); err != nil {
+			return nil, err
+		}
+		t.Timestamp = time.UnixMilli(ts)
+		ticks = append(ticks, t)
+	}
+	return ticks, rows.Err()
+}
+
 func (r *SQLiteRepository) CreateEvent(ctx context.Context, event *models.Event) error {
-	query := `INSERT INTO events (type, mint, pair, lp_address, timestamp, raw)
-			  VALUES (?, ?, ?, ?, ?, ?)`
+	query := `INSERT INTO events (type, mint, pair, lp_address, lp_mint, timestamp, raw)
+			  VALUES (?, ?, ?, ?, ?, ?, ?)`
 	result, err := r.db.ExecContext(ctx, query,
 		event.Type,
 		event.Mint,
 		event.Pair,
 		event.LPAddress,
+		event.LPMint,
 		event.Timestamp.Unix(),
 		event.Raw,
 	)
@@ -288,7 +557,7 @@ func (r *SQLiteRepository) CreateEvent(ctx context.Context, event *models.Event)
 }
 
 func (r *SQLiteRepository) GetRecentEvents(ctx context.Context, limit int) ([]models.Event, error) {
-	query := `SELECT id, type, mint, pair, lp_address, timestamp, raw
+	query := `SELECT id, type, mint, pair, lp_address, COALESCE(lp_mint, ''), timestamp, raw
 			  FROM events ORDER BY timestamp DESC LIMIT ?`
 	rows, err := r.db.QueryContext(ctx, query, limit)
 	if err != nil {
@@ -300,7 +569,7 @@ func (r *SQLiteRepository) GetRecentEvents(ctx context.Context, limit int) ([]mo
 	for rows.Next() {
 		var e models.Event
 		var ts int64
-		err := rows.Scan(&e.ID, &e.Type, &e.Mint, &e.Pair, &e.LPAddress, &ts, &e.Raw)
+		err := rows.Scan(&e.ID, &e.Type, &e.Mint, &e.Pair, &e.LPAddress, &e.LPMint, &ts, &e.Raw)
 		if err != nil {
 			return nil, err
 		}
@@ -349,6 +618,90 @@ func (r *SQLiteRepository) AddToWhitelist(ctx context.Context, mint string) erro
 	return err
 }
 
+func (r *SQLiteRepository) SaveMintDeployer(ctx context.Context, deployer *models.MintDeployer) error {
+	query := `INSERT OR IGNORE INTO mint_deployers (mint, creator, funder, detected_at) VALUES (?, ?, ?, ?)`
+	_, err := r.db.ExecContext(ctx, query, deployer.Mint, deployer.Creator, deployer.Funder, deployer.DetectedAt.Unix())
+	return err
+}
+
+func (r *SQLiteRepository) GetMintDeployer(ctx context.Context, mint string) (*models.MintDeployer, error) {
+	query := `SELECT mint, creator, COALESCE(funder, ''), detected_at FROM mint_deployers WHERE mint = ?`
+	var d models.MintDeployer
+	var detectedAt int64
+	if err := r.db.QueryRowContext(ctx, query, mint).Scan(&d.Mint, &d.Creator, &d.Funder, &detectedAt); err != nil {
+		return nil, err
+	}
+	d.DetectedAt = time.Unix(detectedAt, 0)
+	return &d, nil
+}
+
+func (r *SQLiteRepository) IsDeployerBlacklisted(ctx context.Context, wallet string) (bool, error) {
+	query := `SELECT COUNT(*) FROM deployer_blacklist WHERE wallet = ?`
+	var count int
+	err := r.db.QueryRowContext(ctx, query, wallet).Scan(&count)
+	return count > 0, err
+}
+
+// BlacklistDeployer blacklists a wallet; the first reason recorded is kept
+func (r *SQLiteRepository) BlacklistDeployer(ctx context.Context, wallet, mint, reason string) error {
+	query := `INSERT OR IGNORE INTO deployer_blacklist (wallet, mint, reason, added_at) VALUES (?, ?, ?, ?)`
+	_, err := r.db.ExecContext(ctx, query, wallet, mint, reason, time.Now().Unix())
+	return err
+}
+
+func (r *SQLiteRepository) RemoveDeployerFromBlacklist(ctx context.Context, wallet string) error {
+	query := `DELETE FROM deployer_blacklist WHERE wallet = ?`
+	_, err := r.db.ExecContext(ctx, query, wallet)
+	return err
+}
+
+// GetDeployerStats lists blacklisted deployers and deployers we have closed
+// trades on, blacklisted first, then by number of closed trades
+func (r *SQLiteRepository) GetDeployerStats(ctx context.Context, limit int) ([]models.DeployerStats, error) {
+	query := `
+		SELECT w.wallet,
+			COALESCE(s.mints, 0), COALESCE(s.closed, 0), COALESCE(s.losses, 0), COALESCE(s.pnl_usd, 0),
+			b.wallet IS NOT NULL, COALESCE(b.reason, ''), COALESCE(b.mint, ''), COALESCE(b.added_at, 0)
+		FROM (SELECT creator AS wallet FROM mint_deployers UNION SELECT wallet FROM deployer_blacklist) w
+		LEFT JOIN (
+			SELECT d.creator,
+				COUNT(DISTINCT d.mint) AS mints,
+				COUNT(p.id) AS closed,
+				SUM(CASE WHEN p.pnl_usd < 0 THEN 1 ELSE 0 END) AS losses,
+				SUM(p.pnl_usd) AS pnl_usd
+			FROM mint_deployers d
+			LEFT JOIN realized_pnl p ON p.mint = d.mint
+			GROUP BY d.creator
+		) s ON s.creator = w.wallet
+		LEFT JOIN deployer_blacklist b ON b.wallet = w.wallet
+		WHERE b.wallet IS NOT NULL OR s.closed > 0
+		ORDER BY b.wallet IS NOT NULL DESC, s.closed DESC, b.added_at DESC
+		LIMIT ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []models.DeployerStats
+	for rows.Next() {
+		var s models.DeployerStats
+		var addedAt int64
+		err := rows.Scan(&s.Wallet, &s.Mints, &s.Closed, &s.Losses, &s.PnLUSD,
+			&s.Blacklisted, &s.BlacklistReason, &s.BlacklistMint, &addedAt)
+		if err != nil {
+			return nil, err
+		}
+		if addedAt > 0 {
+			s.BlacklistedAt = time.Unix(addedAt, 0)
+		}
+		stats = append(stats, s)
+	}
+	return stats, rows.Err()
+}
+
 func (r *SQLiteRepository) GetStrategyStats(ctx context.Context) ([]models.StrategyStats, error) {
 	// Query to aggregate trade statistics by strategy
 	query := `
@@ -358,7 +711,6 @@ func (r *SQLiteRepository) GetStrategyStats(ctx context.Context) ([]models.Strat
 			SUM(CASE WHEN side = 'BUY' THEN 1 ELSE 0 END) as buy_trades,
 			SUM(CASE WHEN side = 'SELL' THEN 1 ELSE 0 END) as sell_trades,
 			AVG(CASE WHEN side = 'BUY' THEN price_usd ELSE NULL END) as avg_entry_price,
-			SUM(CASE WHEN side = 'BUY' THEN price_usd * CAST(quantity AS REAL) ELSE 0 END) as total_volume,
 			SUM(CASE WHEN status = 'EXECUTED' THEN 1 ELSE 0 END) as executed_trades,
 			SUM(CASE WHEN status = 'FAILED' THEN 1 ELSE 0 END) as failed_trades
 		FROM trades
@@ -383,7 +735,6 @@ func (r *SQLiteRepository) GetStrategyStats(ctx context.Context) ([]models.Strat
 			&s.BuyTrades,
 			&s.SellTrades,
 			&avgEntryPrice,
-			&s.TotalVolume,
 			&s.ExecutedTrades,
 			&s.FailedTrades,
 		)
@@ -408,6 +759,27 @@ func (r *SQLiteRepository) GetStrategyStats(ctx context.Context) ([]models.Strat
 			return nil, err
 		}
 
+		// Volume is what was spent entering positions, open or closed
+		volQuery := `SELECT COALESCE((SELECT SUM(cost_usd) FROM positions WHERE COALESCE(strategy, 'custom') = ?), 0) +
+					 COALESCE((SELECT SUM(entry_cost_usd) FROM realized_pnl WHERE COALESCE(strategy, 'custom') = ?), 0)`
+		err = r.db.QueryRowContext(ctx, volQuery, s.Strategy, s.Strategy).Scan(&s.TotalVolume)
+		if err != nil {
+			return nil, err
+		}
+
+		// Realized PnL from closed positions for this strategy
+		var wins int
+		pnlQuery := `SELECT COUNT(*), COALESCE(SUM(CASE WHEN pnl_usd > 0 THEN 1 ELSE 0 END), 0), COALESCE(SUM(pnl_usd), 0),
+					 COALESCE(AVG(mfe_pct), 0), COALESCE(AVG(mae_pct), 0)
+					 FROM realized_pnl WHERE COALESCE(strategy, 'custom') = ?`
+		err = r.db.QueryRowContext(ctx, pnlQuery, s.Strategy).Scan(&s.ClosedTrades, &wins, &s.RealizedPnLUSD, &s.AvgMFEPct, &s.AvgMAEPct)
+		if err != nil {
+			return nil, err
+		}
+		if s.ClosedTrades > 0 {
+			s.WinRate = (float64(wins) / float64(s.ClosedTrades)) * 100
+		}
+
 		stats = append(stats, s)
 	}
 
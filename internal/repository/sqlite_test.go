@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/speier/tokenscout/internal/models"
+)
+
+func newTestRepo(t *testing.T) *SQLiteRepository {
+	t.Helper()
+	repo, err := NewSQLite(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("NewSQLite: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	return repo
+}
+
+func TestRealizedPnLLedger(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepo(t)
+
+	now := time.Now().Truncate(time.Second)
+	entries := []*models.RealizedPnL{
+		{Mint: "old", OpenedAt: now.Add(-49 * time.Hour), ClosedAt: now.Add(-48 * time.Hour), PnLSOL: 5, PnLUSD: 500, ExitReason: "take_profit"},
+		{Mint: "win", OpenedAt: now.Add(-2 * time.Hour), ClosedAt: now.Add(-time.Hour), PnLSOL: 0.5, PnLUSD: 50, ExitReason: "take_profit", MFEPct: 80},
+		{Mint: "loss", OpenedAt: now.Add(-time.Hour), ClosedAt: now, PnLSOL: -0.2, PnLUSD: -20, ExitReason: "stop_loss", MAEPct: -40},
+	}
+	for _, entry := range entries {
+		if err := repo.CreateRealizedPnL(ctx, entry); err != nil {
+			t.Fatalf("CreateRealizedPnL(%s): %v", entry.Mint, err)
+		}
+		if entry.ID == 0 {
+			t.Errorf("CreateRealizedPnL(%s) left ID unset", entry.Mint)
+		}
+	}
+
+	summary, err := repo.GetPnLSummary(ctx, now.Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("GetPnLSummary: %v", err)
+	}
+	want := models.PnLSummary{Closed: 2, Wins: 1, PnLSOL: 0.3, PnLUSD: 30}
+	if summary.Closed != want.Closed || summary.Wins != want.Wins || summary.PnLUSD != want.PnLUSD {
+		t.Errorf("GetPnLSummary = %+v, want %+v", *summary, want)
+	}
+
+	recent, err := repo.GetRecentRealizedPnL(ctx, 2)
+	if err != nil {
+		t.Fatalf("GetRecentRealizedPnL: %v", err)
+	}
+	if len(recent) != 2 || recent[0].Mint != "loss" || recent[1].Mint != "win" {
+		t.Fatalf("GetRecentRealizedPnL = %+v, want loss then win", recent)
+	}
+	if recent[0].MAEPct != -40 || recent[1].MFEPct != 80 || !recent[0].ClosedAt.Equal(now) {
+		t.Errorf("GetRecentRealizedPnL did not round-trip excursions and times: %+v", recent)
+	}
+}
+
+func TestTradeFillAndStrategyVolume(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepo(t)
+
+	// A pending buy holds the SOL it means to spend, the fill the tokens it got
+	trade := &models.Trade{Timestamp: time.Now(), Side: models.TradeSideBuy, Mint: "mint", Quantity: "0.5", Status: models.TradeStatusPending, Strategy: "sniper"}
+	if err := repo.CreateTrade(ctx, trade); err != nil {
+		t.Fatalf("CreateTrade: %v", err)
+	}
+	if err := repo.UpdateTradeFill(ctx, trade.ID, "1000.5", "1000500000", 0.05, 0); err != nil {
+		t.Fatalf("UpdateTradeFill: %v", err)
+	}
+	filled, err := repo.GetTradeByID(ctx, trade.ID)
+	if err != nil {
+		t.Fatalf("GetTradeByID: %v", err)
+	}
+	if filled.Quantity != "1000.5" || filled.RawAmount != "1000500000" || filled.PriceUSD != 0.05 {
+		t.Errorf("filled trade = %s (%s raw) at %v, want 1000.5 (1000500000 raw) at 0.05", filled.Quantity, filled.RawAmount, filled.PriceUSD)
+	}
+
+	// Volume counts the entry cost of open and closed positions alike
+	if err := repo.CreatePosition(ctx, &models.Position{Mint: "open", Quantity: "1", RawAmount: "1", InitialRaw: "1", CostUSD: 50, OpenedAt: time.Now(), LastUpdateAt: time.Now(), Strategy: "sniper"}); err != nil {
+		t.Fatalf("CreatePosition: %v", err)
+	}
+	if err := repo.CreateRealizedPnL(ctx, &models.RealizedPnL{Mint: "closed", Strategy: "sniper", OpenedAt: time.Now(), ClosedAt: time.Now(), EntryCostUSD: 25}); err != nil {
+		t.Fatalf("CreateRealizedPnL: %v", err)
+	}
+
+	stats, err := repo.GetStrategyStats(ctx)
+	if err != nil {
+		t.Fatalf("GetStrategyStats: %v", err)
+	}
+	if len(stats) != 1 || stats[0].Strategy != "sniper" || stats[0].TotalVolume != 75 {
+		t.Errorf("GetStrategyStats = %+v, want sniper with 75 USD volume", stats)
+	}
+}
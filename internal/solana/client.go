@@ -2,7 +2,9 @@ package solana
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
+	"strconv"
 
 	"github.com/gagliardetto/solana-go"
 	"github.com/gagliardetto/solana-go/rpc"
@@ -46,8 +48,8 @@ func (c *Client) GetRecentBlockhash(ctx context.Context) (solana.Hash, error) {
 
 func (c *Client) SendTransaction(ctx context.Context, tx *solana.Transaction) (solana.Signature, error) {
 	sig, err := c.rpc.SendTransactionWithOpts(ctx, tx, rpc.TransactionOpts{
-		SkipPreflight: false,
-		PreflightCommitment: rpc.CommitmentFinalized,
+		SkipPreflight:       false,
+		PreflightCommitment: rpc.CommitmentConfirmed,
 	})
 	if err != nil {
 		return solana.Signature{}, fmt.Errorf("failed to send transaction: %w", err)
@@ -55,12 +57,170 @@ func (c *Client) SendTransaction(ctx context.Context, tx *solana.Transaction) (s
 	return sig, nil
 }
 
-func (c *Client) ConfirmTransaction(ctx context.Context, sig solana.Signature) error {
-	_, err := c.rpc.GetSignatureStatuses(ctx, true, sig)
+// GetTokenBalance returns the wallet's total raw balance of a mint across all
+// its token accounts
+func (c *Client) GetTokenBalance(ctx context.Context, mintAddress string) (uint64, error) {
+	if c.wallet == nil {
+		return 0, fmt.Errorf("no wallet loaded")
+	}
+
+	mint, err := solana.PublicKeyFromBase58(mintAddress)
+	if err != nil {
+		return 0, fmt.Errorf("invalid mint address: %w", err)
+	}
+
+	accounts, err := c.rpc.GetTokenAccountsByOwner(
+		ctx,
+		c.wallet.PublicKey,
+		&rpc.GetTokenAccountsConfig{Mint: &mint},
+		&rpc.GetTokenAccountsOpts{
+			Commitment: rpc.CommitmentConfirmed,
+			Encoding:   solana.EncodingBase64,
+		},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get token accounts: %w", err)
+	}
+
+	var total uint64
+	for _, account := range accounts.Value {
+		data := account.Account.Data.GetBinary()
+		if len(data) < 72 {
+			continue
+		}
+		// Offset 64-72: amount (u64)
+		total += binary.LittleEndian.Uint64(data[64:72])
+	}
+
+	return total, nil
+}
+
+// GetTokenBalances returns the wallet's raw balance of every mint it holds,
+// across both the SPL Token and Token-2022 programs. Empty accounts are skipped.
+func (c *Client) GetTokenBalances(ctx context.Context) (map[string]uint64, error) {
+	if c.wallet == nil {
+		return nil, fmt.Errorf("no wallet loaded")
+	}
+
+	balances := make(map[string]uint64)
+	for _, program := range []solana.PublicKey{solana.TokenProgramID, solana.Token2022ProgramID} {
+		programID := program
+		accounts, err := c.rpc.GetTokenAccountsByOwner(
+			ctx,
+			c.wallet.PublicKey,
+			&rpc.GetTokenAccountsConfig{ProgramId: &programID},
+			&rpc.GetTokenAccountsOpts{
+				Commitment: rpc.CommitmentConfirmed,
+				Encoding:   solana.EncodingBase64,
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get token accounts: %w", err)
+		}
+
+		for _, account := range accounts.Value {
+			data := account.Account.Data.GetBinary()
+			if len(data) < 72 {
+				continue
+			}
+			// Offset 0-32: mint, 64-72: amount (u64)
+			amount := binary.LittleEndian.Uint64(data[64:72])
+			if amount == 0 {
+				continue
+			}
+			mint := solana.PublicKeyFromBytes(data[0:32]).String()
+			balances[mint] += amount
+		}
+	}
+
+	return balances, nil
+}
+
+// GetTransactionFee returns the network fee in lamports paid by a landed transaction
+func (c *Client) GetTransactionFee(ctx context.Context, sig solana.Signature) (uint64, error) {
+	meta, err := c.getTransactionMeta(ctx, sig)
+	if err != nil {
+		return 0, err
+	}
+	return meta.Fee, nil
+}
+
+// GetTransactionSOLChange returns how many lamports the fee payer's balance
+// changed by in a landed transaction (negative when SOL was spent), along with
+// the fee, which is already included in the change
+func (c *Client) GetTransactionSOLChange(ctx context.Context, sig solana.Signature) (int64, uint64, error) {
+	meta, err := c.getTransactionMeta(ctx, sig)
 	if err != nil {
-		return fmt.Errorf("failed to confirm transaction: %w", err)
+		return 0, 0, err
+	}
+	if len(meta.PreBalances) == 0 || len(meta.PostBalances) == 0 {
+		return 0, 0, fmt.Errorf("transaction balances not available")
 	}
-	return nil
+	// The fee payer is always the first account
+	return int64(meta.PostBalances[0]) - int64(meta.PreBalances[0]), meta.Fee, nil
+}
+
+// GetTransactionTokenChange returns how much the wallet's raw balance of a
+// mint changed by in a landed transaction (negative when tokens left it)
+func (c *Client) GetTransactionTokenChange(ctx context.Context, sig solana.Signature, mintAddress string) (int64, error) {
+	if c.wallet == nil {
+		return 0, fmt.Errorf("no wallet loaded")
+	}
+	mint, err := solana.PublicKeyFromBase58(mintAddress)
+	if err != nil {
+		return 0, fmt.Errorf("invalid mint address: %w", err)
+	}
+
+	meta, err := c.getTransactionMeta(ctx, sig)
+	if err != nil {
+		return 0, err
+	}
+
+	// A token account missing from the pre balances was created by the transaction
+	walletTotal := func(balances []rpc.TokenBalance) (int64, error) {
+		var total int64
+		for _, balance := range balances {
+			if !balance.Mint.Equals(mint) || balance.Owner == nil || !balance.Owner.Equals(c.wallet.PublicKey) || balance.UiTokenAmount == nil {
+				continue
+			}
+			amount, err := strconv.ParseInt(balance.UiTokenAmount.Amount, 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid token amount: %w", err)
+			}
+			total += amount
+		}
+		return total, nil
+	}
+
+	pre, err := walletTotal(meta.PreTokenBalances)
+	if err != nil {
+		return 0, err
+	}
+	post, err := walletTotal(meta.PostTokenBalances)
+	if err != nil {
+		return 0, err
+	}
+	return post - pre, nil
+}
+
+func (c *Client) getTransactionMeta(ctx context.Context, sig solana.Signature) (*rpc.TransactionMeta, error) {
+	maxVersion := uint64(0)
+	tx, err := c.rpc.GetTransaction(ctx, sig, &rpc.GetTransactionOpts{
+		Commitment:                     rpc.CommitmentConfirmed,
+		MaxSupportedTransactionVersion: &maxVersion,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+	if tx == nil || tx.Meta == nil {
+		return nil, fmt.Errorf("transaction meta not available")
+	}
+	return tx.Meta, nil
+}
+
+// RPC returns the underlying RPC client
+func (c *Client) RPC() *rpc.Client {
+	return c.rpc
 }
 
 func (c *Client) GetWallet() *Wallet {
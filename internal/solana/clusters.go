@@ -0,0 +1,96 @@
+package solana
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// clusterCandidates is how many of the largest holders are traced back to
+// their funder; each costs two RPC calls
+const clusterCandidates = 20
+
+// HolderCluster is a group of holder wallets funded by the same source around
+// launch, most likely one buyer split across wallets
+type HolderCluster struct {
+	Funder  solana.PublicKey
+	Wallets []solana.PublicKey
+	Amount  uint64 // Tokens held across the wallets
+}
+
+// GetLaunchSlot returns the slot of the mint's first transaction
+func GetLaunchSlot(ctx context.Context, client *rpc.Client, mintAddress string) (uint64, error) {
+	mint, err := solana.PublicKeyFromBase58(mintAddress)
+	if err != nil {
+		return 0, fmt.Errorf("invalid mint address: %w", err)
+	}
+	oldest, err := oldestSignature(ctx, client, mint)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find mint creation: %w", err)
+	}
+	return oldest.Slot, nil
+}
+
+// FindHolderClusters groups the largest holders by the wallet that paid for
+// their first transaction. Only wallets whose first transaction landed within
+// windowSlots of launchSlot are traced: fresh wallets funded right around
+// launch are the sybil pattern, long-lived wallets share funders (exchanges)
+// innocently. A funder that holds tokens itself joins its cluster. Wallets
+// that can't be traced are left out. Clusters of two or more wallets are
+// returned, largest first.
+func FindHolderClusters(ctx context.Context, client *rpc.Client, dist *HolderDistribution, launchSlot, windowSlots uint64) []HolderCluster {
+	holdings := make(map[solana.PublicKey]uint64, len(dist.Holders))
+	for _, holding := range dist.Holders {
+		holdings[holding.Owner] = holding.Amount
+	}
+
+	n := len(dist.Holders)
+	if n > clusterCandidates {
+		n = clusterCandidates
+	}
+
+	byFunder := make(map[solana.PublicKey][]solana.PublicKey)
+	for _, holding := range dist.Holders[:n] {
+		if ctx.Err() != nil {
+			break
+		}
+		oldest, err := oldestSignature(ctx, client, holding.Owner)
+		if err != nil || !withinSlots(oldest.Slot, launchSlot, windowSlots) {
+			continue
+		}
+		funder, err := feePayer(ctx, client, oldest.Signature)
+		if err != nil || funder.Equals(holding.Owner) {
+			continue
+		}
+		byFunder[funder] = append(byFunder[funder], holding.Owner)
+	}
+
+	var clusters []HolderCluster
+	for funder, wallets := range byFunder {
+		if _, holds := holdings[funder]; holds {
+			wallets = append(wallets, funder)
+		}
+		if len(wallets) < 2 {
+			continue
+		}
+		cluster := HolderCluster{Funder: funder, Wallets: wallets}
+		for _, wallet := range wallets {
+			cluster.Amount += holdings[wallet]
+		}
+		clusters = append(clusters, cluster)
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].Amount > clusters[j].Amount
+	})
+	return clusters
+}
+
+func withinSlots(slot, launchSlot, windowSlots uint64) bool {
+	if slot > launchSlot {
+		return slot-launchSlot <= windowSlots
+	}
+	return launchSlot-slot <= windowSlots
+}
@@ -0,0 +1,213 @@
+package solana
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
+)
+
+// TxOutcome is the final state of a sent transaction
+type TxOutcome string
+
+const (
+	TxConfirmed TxOutcome = "confirmed" // Reached the requested commitment
+	TxFailed    TxOutcome = "failed"    // Landed on chain with a program error
+	TxExpired   TxOutcome = "expired"   // Blockhash expired before it landed
+	TxRejected  TxOutcome = "rejected"  // Failed preflight, so it was never sent
+)
+
+const (
+	confirmPollInterval = 1 * time.Second
+	resendInterval      = 2 * time.Second
+)
+
+// TxResult describes how a transaction ended up
+type TxResult struct {
+	Signature  solana.Signature
+	Outcome    TxOutcome
+	Slot       uint64
+	ProgramErr interface{} // On-chain error when Outcome is TxFailed, the preflight error when TxRejected
+	Sends      int         // Number of times the transaction was sent
+}
+
+// Err returns a descriptive error for failed or expired outcomes
+func (r *TxResult) Err() error {
+	switch r.Outcome {
+	case TxFailed:
+		return fmt.Errorf("transaction %s failed: %v", r.Signature, r.ProgramErr)
+	case TxExpired:
+		return fmt.Errorf("transaction %s expired before confirmation", r.Signature)
+	case TxRejected:
+		return fmt.Errorf("transaction %s rejected at preflight: %v", r.Signature, r.ProgramErr)
+	}
+	return nil
+}
+
+// SendAndConfirm sends a signed transaction and keeps resending it until it
+// reaches the requested commitment, fails on chain, or lastValidBlockHeight
+// passes. A transaction the RPC node rejects at preflight was never sent and
+// is reported as TxRejected. An error is only returned when the outcome
+// cannot be determined.
+func (c *Client) SendAndConfirm(
+	ctx context.Context,
+	tx *solana.Transaction,
+	lastValidBlockHeight uint64,
+	commitment rpc.CommitmentType,
+) (*TxResult, error) {
+	if len(tx.Signatures) == 0 {
+		return nil, fmt.Errorf("transaction is not signed")
+	}
+
+	result := &TxResult{Signature: tx.Signatures[0]}
+
+	// First send runs preflight so obviously broken swaps fail fast
+	if _, err := c.SendTransaction(ctx, tx); err != nil {
+		// The node answered with an error, so the transaction never left it.
+		// Any other failure (e.g. a timeout) may still have reached it.
+		var rpcErr *jsonrpc.RPCError
+		if errors.As(err, &rpcErr) {
+			result.Outcome = TxRejected
+			result.ProgramErr = rpcErr
+			return result, nil
+		}
+		return nil, err
+	}
+	result.Sends++
+
+	pollTicker := time.NewTicker(confirmPollInterval)
+	defer pollTicker.Stop()
+	lastSend := time.Now()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return result, fmt.Errorf("confirmation aborted: %w", ctx.Err())
+		case <-pollTicker.C:
+		}
+
+		done, err := c.checkStatus(ctx, result, commitment)
+		if err == nil && done {
+			return result, nil
+		}
+
+		height, err := c.rpc.GetBlockHeight(ctx, rpc.CommitmentConfirmed)
+		if err != nil {
+			continue
+		}
+
+		if height > lastValidBlockHeight {
+			// One last look - it may have landed right before expiry
+			if done, err := c.checkStatus(ctx, result, commitment); err == nil && done {
+				return result, nil
+			}
+			result.Outcome = TxExpired
+			return result, nil
+		}
+
+		if time.Since(lastSend) >= resendInterval {
+			if err := c.resendTransaction(ctx, tx); err == nil {
+				result.Sends++
+			}
+			lastSend = time.Now()
+		}
+	}
+}
+
+// ConfirmTransaction polls the signature status until the transaction reaches
+// confirmed commitment, fails on chain, or the context is done
+func (c *Client) ConfirmTransaction(ctx context.Context, sig solana.Signature) error {
+	result := &TxResult{Signature: sig}
+
+	ticker := time.NewTicker(confirmPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if done, err := c.checkStatus(ctx, result, rpc.CommitmentConfirmed); err == nil && done {
+			return result.Err()
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to confirm transaction: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// ParseSignature parses a base58 transaction signature
+func ParseSignature(sig string) (solana.Signature, error) {
+	parsed, err := solana.SignatureFromBase58(sig)
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("invalid signature: %w", err)
+	}
+	return parsed, nil
+}
+
+// GetTransactionStatus looks up a signature once. It returns nil when the
+// cluster has no record of it (never landed or too old for the status cache).
+func (c *Client) GetTransactionStatus(ctx context.Context, sig solana.Signature) (*TxResult, error) {
+	result := &TxResult{Signature: sig}
+	done, err := c.checkStatus(ctx, result, rpc.CommitmentConfirmed)
+	if err != nil {
+		return nil, err
+	}
+	if !done {
+		return nil, nil
+	}
+	return result, nil
+}
+
+// checkStatus fills in the result and reports true once the transaction has
+// either failed or reached the requested commitment
+func (c *Client) checkStatus(ctx context.Context, result *TxResult, commitment rpc.CommitmentType) (bool, error) {
+	statuses, err := c.rpc.GetSignatureStatuses(ctx, true, result.Signature)
+	if err != nil {
+		return false, fmt.Errorf("failed to get signature status: %w", err)
+	}
+	if len(statuses.Value) == 0 || statuses.Value[0] == nil {
+		return false, nil
+	}
+
+	status := statuses.Value[0]
+	result.Slot = status.Slot
+
+	if status.Err != nil {
+		result.Outcome = TxFailed
+		result.ProgramErr = status.Err
+		return true, nil
+	}
+
+	if commitmentReached(status.ConfirmationStatus, commitment) {
+		result.Outcome = TxConfirmed
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// resendTransaction rebroadcasts an already-sent transaction without preflight
+func (c *Client) resendTransaction(ctx context.Context, tx *solana.Transaction) error {
+	maxRetries := uint(0)
+	_, err := c.rpc.SendTransactionWithOpts(ctx, tx, rpc.TransactionOpts{
+		SkipPreflight: true,
+		MaxRetries:    &maxRetries,
+	})
+	return err
+}
+
+func commitmentReached(status rpc.ConfirmationStatusType, want rpc.CommitmentType) bool {
+	switch want {
+	case rpc.CommitmentProcessed:
+		return status != ""
+	case rpc.CommitmentFinalized:
+		return status == rpc.ConfirmationStatusFinalized
+	default:
+		return status == rpc.ConfirmationStatusConfirmed ||
+			status == rpc.ConfirmationStatusFinalized
+	}
+}
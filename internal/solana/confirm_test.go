@@ -0,0 +1,207 @@
+package solana
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
+)
+
+func TestCommitmentReached(t *testing.T) {
+	tests := []struct {
+		status rpc.ConfirmationStatusType
+		want   rpc.CommitmentType
+		ok     bool
+	}{
+		{"", rpc.CommitmentProcessed, false},
+		{rpc.ConfirmationStatusProcessed, rpc.CommitmentProcessed, true},
+		{rpc.ConfirmationStatusProcessed, rpc.CommitmentConfirmed, false},
+		{rpc.ConfirmationStatusConfirmed, rpc.CommitmentConfirmed, true},
+		{rpc.ConfirmationStatusFinalized, rpc.CommitmentConfirmed, true},
+		{rpc.ConfirmationStatusConfirmed, rpc.CommitmentFinalized, false},
+		{rpc.ConfirmationStatusFinalized, rpc.CommitmentFinalized, true},
+	}
+	for _, tt := range tests {
+		if got := commitmentReached(tt.status, tt.want); got != tt.ok {
+			t.Errorf("commitmentReached(%q, %q) = %v, want %v", tt.status, tt.want, got, tt.ok)
+		}
+	}
+}
+
+func TestTxResultErr(t *testing.T) {
+	tests := []struct {
+		outcome TxOutcome
+		want    string // "" for no error
+	}{
+		{TxConfirmed, ""},
+		{TxFailed, "failed: custom program error"},
+		{TxExpired, "expired before confirmation"},
+		{TxRejected, "rejected at preflight: custom program error"},
+	}
+	for _, tt := range tests {
+		err := (&TxResult{Outcome: tt.outcome, ProgramErr: "custom program error"}).Err()
+		switch {
+		case tt.want == "" && err != nil:
+			t.Errorf("Err(%s) = %v, want nil", tt.outcome, err)
+		case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+			t.Errorf("Err(%s) = %v, want it to contain %q", tt.outcome, err, tt.want)
+		}
+	}
+}
+
+// fakeNode is a JSON-RPC node scripted per method
+type fakeNode struct {
+	mu          sync.Mutex
+	calls       map[string]int
+	sendErr     *jsonrpc.RPCError                     // Answer to the preflighted send
+	status      func(call int) map[string]interface{} // nil for an unknown signature
+	blockHeight uint64
+}
+
+func (n *fakeNode) serve(t *testing.T) *Client {
+	n.calls = make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("bad request: %v", err)
+			return
+		}
+		n.mu.Lock()
+		n.calls[req.Method]++
+		call := n.calls[req.Method]
+		n.mu.Unlock()
+
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		switch req.Method {
+		case "sendTransaction":
+			if call == 1 && n.sendErr != nil {
+				response["error"] = n.sendErr
+			} else {
+				response["result"] = testSignature.String()
+			}
+		case "getSignatureStatuses":
+			var status interface{}
+			if n.status != nil {
+				if s := n.status(call); s != nil {
+					status = s
+				}
+			}
+			response["result"] = map[string]interface{}{"context": map[string]interface{}{"slot": 1}, "value": []interface{}{status}}
+		case "getBlockHeight":
+			response["result"] = n.blockHeight
+		default:
+			t.Errorf("unexpected method %s", req.Method)
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+	return NewClient(server.URL, nil)
+}
+
+var testSignature = solana.Signature{1, 2, 3}
+
+func testTransaction() *solana.Transaction {
+	payer := solana.NewWallet().PublicKey()
+	return &solana.Transaction{
+		Signatures: []solana.Signature{testSignature},
+		Message: solana.Message{
+			AccountKeys: []solana.PublicKey{payer},
+			Header:      solana.MessageHeader{NumRequiredSignatures: 1},
+		},
+	}
+}
+
+func confirmedStatus(call int) map[string]interface{} {
+	return map[string]interface{}{"slot": 42, "confirmations": nil, "err": nil, "confirmationStatus": "confirmed"}
+}
+
+func TestSendAndConfirm(t *testing.T) {
+	tests := []struct {
+		name      string
+		node      *fakeNode
+		want      TxOutcome
+		wantSlot  uint64
+		wantSends int
+	}{
+		{
+			name:      "rejected at preflight",
+			node:      &fakeNode{sendErr: &jsonrpc.RPCError{Code: -32002, Message: "Transaction simulation failed"}},
+			want:      TxRejected,
+			wantSends: 0,
+		},
+		{
+			name:      "confirmed",
+			node:      &fakeNode{status: confirmedStatus, blockHeight: 100},
+			want:      TxConfirmed,
+			wantSlot:  42,
+			wantSends: 1,
+		},
+		{
+			name: "failed on chain",
+			node: &fakeNode{blockHeight: 100, status: func(call int) map[string]interface{} {
+				return map[string]interface{}{"slot": 43, "err": map[string]interface{}{"InstructionError": []interface{}{0, "Custom"}}, "confirmationStatus": "processed"}
+			}},
+			want:      TxFailed,
+			wantSlot:  43,
+			wantSends: 1,
+		},
+		{
+			name:      "expired",
+			node:      &fakeNode{blockHeight: 201},
+			want:      TxExpired,
+			wantSends: 1,
+		},
+		{
+			name: "landed right before expiry",
+			node: &fakeNode{blockHeight: 201, status: func(call int) map[string]interface{} {
+				if call == 1 {
+					return nil // Not seen on the regular poll
+				}
+				return confirmedStatus(call)
+			}},
+			want:      TxConfirmed,
+			wantSlot:  42,
+			wantSends: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := tt.node.serve(t)
+
+			result, err := client.SendAndConfirm(context.Background(), testTransaction(), 200, rpc.CommitmentConfirmed)
+			if err != nil {
+				t.Fatalf("SendAndConfirm: %v", err)
+			}
+			if result.Outcome != tt.want || result.Slot != tt.wantSlot || result.Sends != tt.wantSends {
+				t.Errorf("SendAndConfirm = %s at slot %d after %d sends, want %s at slot %d after %d",
+					result.Outcome, result.Slot, result.Sends, tt.want, tt.wantSlot, tt.wantSends)
+			}
+			if tt.want == TxRejected {
+				if rpcErr, ok := result.ProgramErr.(*jsonrpc.RPCError); !ok || rpcErr.Code != -32002 {
+					t.Errorf("ProgramErr = %v, want the preflight error", result.ProgramErr)
+				}
+			}
+		})
+	}
+}
+
+func TestSendAndConfirmTransportError(t *testing.T) {
+	// A send that may or may not have reached the node has no known outcome
+	client := NewClient("http://127.0.0.1:1", nil)
+	if result, err := client.SendAndConfirm(context.Background(), testTransaction(), 200, rpc.CommitmentConfirmed); err == nil {
+		t.Errorf("SendAndConfirm = %+v, want an error", result)
+	}
+}
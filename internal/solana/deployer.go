@@ -0,0 +1,87 @@
+package solana
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// Deployer identifies who launched a token
+type Deployer struct {
+	Creator string // Fee payer of the mint's first transaction
+	Funder  string // Fee payer of the creator's first transaction, "" when the creator paid it
+}
+
+// GetDeployer finds the wallet that created a mint and the wallet that first
+// funded it. Fresh deployer wallets are usually funded by a transfer the
+// funder pays for, so its fee payer is the money's source. An unknown funder
+// is not an error.
+func GetDeployer(ctx context.Context, client *rpc.Client, mintAddress string) (*Deployer, error) {
+	mint, err := solana.PublicKeyFromBase58(mintAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mint address: %w", err)
+	}
+
+	creator, err := firstFeePayer(ctx, client, mint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find mint creator: %w", err)
+	}
+
+	deployer := &Deployer{Creator: creator.String()}
+	if funder, err := firstFeePayer(ctx, client, creator); err == nil && !funder.Equals(creator) {
+		deployer.Funder = funder.String()
+	}
+	return deployer, nil
+}
+
+// firstFeePayer returns the fee payer of the oldest transaction touching the
+// account
+func firstFeePayer(ctx context.Context, client *rpc.Client, account solana.PublicKey) (solana.PublicKey, error) {
+	oldest, err := oldestSignature(ctx, client, account)
+	if err != nil {
+		return solana.PublicKey{}, err
+	}
+	return feePayer(ctx, client, oldest.Signature)
+}
+
+// oldestSignature returns the account's first transaction. Only the newest
+// 1000 signatures are searched, which covers fresh mints and wallets.
+func oldestSignature(ctx context.Context, client *rpc.Client, account solana.PublicKey) (*rpc.TransactionSignature, error) {
+	sigs, err := client.GetSignaturesForAddress(ctx, account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signatures: %w", err)
+	}
+	if len(sigs) == 0 {
+		return nil, fmt.Errorf("no transactions found")
+	}
+	return sigs[len(sigs)-1], nil
+}
+
+// feePayer returns the wallet that paid for a transaction
+func feePayer(ctx context.Context, client *rpc.Client, signature solana.Signature) (solana.PublicKey, error) {
+	maxVersion := uint64(0)
+	tx, err := client.GetTransaction(ctx, signature, &rpc.GetTransactionOpts{
+		Encoding:                       solana.EncodingBase64,
+		Commitment:                     rpc.CommitmentConfirmed,
+		MaxSupportedTransactionVersion: &maxVersion,
+	})
+	if err != nil {
+		return solana.PublicKey{}, fmt.Errorf("failed to get transaction: %w", err)
+	}
+	if tx == nil || tx.Transaction == nil {
+		return solana.PublicKey{}, fmt.Errorf("transaction not found")
+	}
+
+	parsed, err := tx.Transaction.GetTransaction()
+	if err != nil {
+		return solana.PublicKey{}, fmt.Errorf("failed to decode transaction: %w", err)
+	}
+	if len(parsed.Message.AccountKeys) == 0 {
+		return solana.PublicKey{}, fmt.Errorf("transaction has no accounts")
+	}
+
+	// The fee payer is always the first account
+	return parsed.Message.AccountKeys[0], nil
+}
@@ -0,0 +1,135 @@
+package solana
+
+import (
+	"context"
+	"sort"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// RaydiumAMMAuthority owns the vaults and LP of every Raydium AMM V4 pool
+var RaydiumAMMAuthority = solana.MustPublicKeyFromBase58("5Q544fKrFoe6tsEbD7S8EmxGTJYAKtTVhAW5Q5pge4j1")
+
+// nonHolderOwners own tokens that aren't in anyone's hands: pool authorities
+// and burn addresses
+var nonHolderOwners = map[solana.PublicKey]bool{
+	RaydiumAMMAuthority:    true,
+	IncineratorAddress:     true,
+	solana.SystemProgramID: true,
+}
+
+// poolStatePrograms own pool accounts that hold their vaults directly (an
+// Orca Whirlpool is the owner of its own token vaults)
+var poolStatePrograms = map[solana.PublicKey]bool{
+	RaydiumAMMV4Program:  true,
+	OrcaWhirlpoolProgram: true,
+}
+
+// holderOwnerChecks is how many of the largest wallets are checked for being
+// a pool account rather than a wallet
+const holderOwnerChecks = 20
+
+// WalletHolding is one wallet's balance across its token accounts
+type WalletHolding struct {
+	Owner  solana.PublicKey
+	Amount uint64
+}
+
+// HolderDistribution is how a token's supply is spread across real holders
+type HolderDistribution struct {
+	Supply   uint64          // Every token in an account, pools and burns included
+	Excluded uint64          // Tokens in pool vaults, with the LP authority or burned
+	Holders  []WalletHolding // Wallets, largest first
+}
+
+// GetHolderDistribution groups token accounts by wallet and leaves out pool
+// vaults, the Raydium LP authority and burn addresses. poolAddress, when
+// known, is excluded as an owner too. Shares are of the whole supply, so a
+// fresh pool holding most tokens doesn't inflate everyone else's share.
+func GetHolderDistribution(ctx context.Context, client *rpc.Client, accounts []TokenAccountInfo, poolAddress string) *HolderDistribution {
+	excluded := make(map[solana.PublicKey]bool, len(nonHolderOwners)+1)
+	for owner := range nonHolderOwners {
+		excluded[owner] = true
+	}
+	if pool, err := solana.PublicKeyFromBase58(poolAddress); err == nil {
+		excluded[pool] = true
+	}
+
+	dist := &HolderDistribution{}
+	byOwner := make(map[solana.PublicKey]uint64)
+	for _, account := range accounts {
+		dist.Supply += account.Amount
+		if excluded[account.Owner] {
+			dist.Excluded += account.Amount
+			continue
+		}
+		byOwner[account.Owner] += account.Amount
+	}
+
+	for owner, amount := range byOwner {
+		dist.Holders = append(dist.Holders, WalletHolding{Owner: owner, Amount: amount})
+	}
+	sort.Slice(dist.Holders, func(i, j int) bool {
+		return dist.Holders[i].Amount > dist.Holders[j].Amount
+	})
+
+	dist.excludePoolAccounts(ctx, client)
+	return dist
+}
+
+// excludePoolAccounts drops large "holders" that are pool state accounts.
+// Failing to check just leaves them in.
+func (d *HolderDistribution) excludePoolAccounts(ctx context.Context, client *rpc.Client) {
+	n := len(d.Holders)
+	if n > holderOwnerChecks {
+		n = holderOwnerChecks
+	}
+	if n == 0 {
+		return
+	}
+
+	owners := make([]solana.PublicKey, n)
+	for i := 0; i < n; i++ {
+		owners[i] = d.Holders[i].Owner
+	}
+	result, err := client.GetMultipleAccounts(ctx, owners...)
+	if err != nil {
+		return
+	}
+
+	kept := d.Holders[:0]
+	for i, holding := range d.Holders {
+		if i < len(result.Value) && result.Value[i] != nil && poolStatePrograms[result.Value[i].Owner] {
+			d.Excluded += holding.Amount
+			continue
+		}
+		kept = append(kept, holding)
+	}
+	d.Holders = kept
+}
+
+// Pct is an amount's share of the supply
+func (d *HolderDistribution) Pct(amount uint64) float64 {
+	if d.Supply == 0 {
+		return 0
+	}
+	return float64(amount) / float64(d.Supply) * 100
+}
+
+// TopHolderPct is the largest wallet's share of the supply
+func (d *HolderDistribution) TopHolderPct() float64 {
+	if len(d.Holders) == 0 {
+		return 0
+	}
+	return d.Pct(d.Holders[0].Amount)
+}
+
+// Top10Pct is the ten largest wallets' share of the supply
+func (d *HolderDistribution) Top10Pct() float64 {
+	var amount uint64
+	for i := 0; i < 10 && i < len(d.Holders); i++ {
+		amount += d.Holders[i].Amount
+	}
+	return d.Pct(amount)
+}
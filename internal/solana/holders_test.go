@@ -0,0 +1,140 @@
+package solana
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// accountsServer answers getMultipleAccounts with an account owned by
+// owners[address] for each listed address, null for the rest
+func accountsServer(t *testing.T, owners map[string]solana.PublicKey) *rpc.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage   `json:"id"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("bad request: %v", err)
+			return
+		}
+		var addresses []string
+		json.Unmarshal(req.Params[0], &addresses)
+
+		value := make([]interface{}, len(addresses))
+		for i, address := range addresses {
+			if owner, ok := owners[address]; ok {
+				value[i] = map[string]interface{}{
+					"lamports":   1,
+					"owner":      owner.String(),
+					"data":       []string{"", "base64"},
+					"executable": false,
+					"rentEpoch":  0,
+				}
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  map[string]interface{}{"context": map[string]interface{}{"slot": 1}, "value": value},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return rpc.New(server.URL)
+}
+
+func TestGetHolderDistribution(t *testing.T) {
+	alice := solana.NewWallet().PublicKey()
+	bob := solana.NewWallet().PublicKey()
+	pool := solana.NewWallet().PublicKey()
+	whirlpool := solana.NewWallet().PublicKey()
+
+	accounts := []TokenAccountInfo{
+		{Owner: alice, Amount: 100},
+		{Owner: alice, Amount: 50}, // Second token account of the same wallet
+		{Owner: bob, Amount: 120},
+		{Owner: RaydiumAMMAuthority, Amount: 400},
+		{Owner: IncineratorAddress, Amount: 30},
+		{Owner: pool, Amount: 100},
+		{Owner: whirlpool, Amount: 200}, // A pool account holding its own vault
+	}
+	client := accountsServer(t, map[string]solana.PublicKey{
+		whirlpool.String(): OrcaWhirlpoolProgram,
+		alice.String():     solana.SystemProgramID,
+	})
+
+	dist := GetHolderDistribution(context.Background(), client, accounts, pool.String())
+
+	if dist.Supply != 1000 {
+		t.Errorf("Supply = %d, want 1000", dist.Supply)
+	}
+	if dist.Excluded != 730 {
+		t.Errorf("Excluded = %d, want 730 (Raydium, burn, pool and whirlpool)", dist.Excluded)
+	}
+	if len(dist.Holders) != 2 || dist.Holders[0].Owner != alice || dist.Holders[0].Amount != 150 || dist.Holders[1].Owner != bob {
+		t.Fatalf("Holders = %+v, want alice 150 then bob 120", dist.Holders)
+	}
+	if got := dist.TopHolderPct(); got != 15 {
+		t.Errorf("TopHolderPct = %v, want 15", got)
+	}
+	if got := dist.Top10Pct(); got != 27 {
+		t.Errorf("Top10Pct = %v, want 27", got)
+	}
+}
+
+func TestGetHolderDistributionWithoutOwnerChecks(t *testing.T) {
+	whirlpool := solana.NewWallet().PublicKey()
+	accounts := []TokenAccountInfo{{Owner: whirlpool, Amount: 200}}
+
+	// Failing to check owners leaves every wallet in
+	dist := GetHolderDistribution(context.Background(), rpc.New("http://127.0.0.1:1"), accounts, "")
+	if len(dist.Holders) != 1 || dist.Excluded != 0 {
+		t.Errorf("dist = %+v, want the unchecked holder kept", dist)
+	}
+}
+
+func TestHolderDistributionShares(t *testing.T) {
+	var empty HolderDistribution
+	if empty.Pct(10) != 0 || empty.TopHolderPct() != 0 || empty.Top10Pct() != 0 {
+		t.Errorf("empty distribution has non-zero shares")
+	}
+
+	dist := &HolderDistribution{Supply: 2000}
+	for i := 0; i < 12; i++ {
+		dist.Holders = append(dist.Holders, WalletHolding{Amount: uint64(200 - i*10)})
+	}
+	if got := dist.Pct(500); got != 25 {
+		t.Errorf("Pct(500) = %v, want 25", got)
+	}
+	if got := dist.TopHolderPct(); got != 10 {
+		t.Errorf("TopHolderPct = %v, want 10", got)
+	}
+	// The ten largest hold 200+190+...+110 = 1550
+	if got := dist.Top10Pct(); got != 77.5 {
+		t.Errorf("Top10Pct = %v, want 77.5", got)
+	}
+}
+
+func TestWithinSlots(t *testing.T) {
+	tests := []struct {
+		slot, launch, window uint64
+		want                 bool
+	}{
+		{1000, 1000, 0, true},
+		{1050, 1000, 50, true},
+		{1051, 1000, 50, false},
+		{950, 1000, 50, true}, // Funded just before launch
+		{949, 1000, 50, false},
+		{0, 1000, 50, false},
+	}
+	for _, tt := range tests {
+		if got := withinSlots(tt.slot, tt.launch, tt.window); got != tt.want {
+			t.Errorf("withinSlots(%d, %d, %d) = %v, want %v", tt.slot, tt.launch, tt.window, got, tt.want)
+		}
+	}
+}
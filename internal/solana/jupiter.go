@@ -168,6 +168,44 @@ func (j *JupiterClient) ExecuteSwap(
 		return solana.Signature{}, fmt.Errorf("failed to get quote: %w", err)
 	}
 
+	return j.SwapWithQuote(ctx, client, quote, priorityFeeMicroLamports)
+}
+
+// SwapWithQuote builds, signs and sends a swap transaction for an
+// already-fetched quote. It does not wait for confirmation.
+func (j *JupiterClient) SwapWithQuote(
+	ctx context.Context,
+	client *Client,
+	quote *QuoteResponse,
+	priorityFeeMicroLamports int64,
+) (solana.Signature, error) {
+	tx, _, err := j.BuildSwapTransaction(ctx, client, quote, priorityFeeMicroLamports)
+	if err != nil {
+		return solana.Signature{}, err
+	}
+
+	// Send transaction
+	sig, err := client.SendTransaction(ctx, tx)
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("failed to send transaction: %w", err)
+	}
+
+	return sig, nil
+}
+
+// BuildSwapTransaction fetches and signs the swap transaction for a quote.
+// It returns the signed transaction and the last block height at which it
+// can still land.
+func (j *JupiterClient) BuildSwapTransaction(
+	ctx context.Context,
+	client *Client,
+	quote *QuoteResponse,
+	priorityFeeMicroLamports int64,
+) (*solana.Transaction, uint64, error) {
+	if client == nil || client.wallet == nil {
+		return nil, 0, fmt.Errorf("no wallet loaded")
+	}
+
 	// Get swap transaction
 	swapResp, err := j.GetSwapTransaction(ctx, SwapRequest{
 		QuoteResponse:                 *quote,
@@ -176,18 +214,18 @@ func (j *JupiterClient) ExecuteSwap(
 		ComputeUnitPriceMicroLamports: &priorityFeeMicroLamports,
 	})
 	if err != nil {
-		return solana.Signature{}, fmt.Errorf("failed to get swap transaction: %w", err)
+		return nil, 0, fmt.Errorf("failed to get swap transaction: %w", err)
 	}
 
 	// Decode transaction
 	txBytes, err := base64.StdEncoding.DecodeString(swapResp.SwapTransaction)
 	if err != nil {
-		return solana.Signature{}, fmt.Errorf("failed to decode transaction: %w", err)
+		return nil, 0, fmt.Errorf("failed to decode transaction: %w", err)
 	}
 
 	tx, err := solana.TransactionFromDecoder(bin.NewBinDecoder(txBytes))
 	if err != nil {
-		return solana.Signature{}, fmt.Errorf("failed to unmarshal transaction: %w", err)
+		return nil, 0, fmt.Errorf("failed to unmarshal transaction: %w", err)
 	}
 
 	// Sign transaction
@@ -198,14 +236,8 @@ func (j *JupiterClient) ExecuteSwap(
 		return nil
 	})
 	if err != nil {
-		return solana.Signature{}, fmt.Errorf("failed to sign transaction: %w", err)
+		return nil, 0, fmt.Errorf("failed to sign transaction: %w", err)
 	}
 
-	// Send transaction
-	sig, err := client.SendTransaction(ctx, tx)
-	if err != nil {
-		return solana.Signature{}, fmt.Errorf("failed to send transaction: %w", err)
-	}
-
-	return sig, nil
+	return tx, uint64(swapResp.LastValidBlockHeight), nil
 }
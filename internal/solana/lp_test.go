@@ -0,0 +1,177 @@
+package solana
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// lpHolder is an LP token account among the mint's largest
+type lpHolder struct {
+	owner   solana.PublicKey // Wallet owning the token account
+	program solana.PublicKey // Program owning that wallet, e.g. a locker for escrows
+	amount  uint64
+}
+
+// lpServer answers the LP supply, largest-accounts and account lookups GetLPLockInfo makes
+func lpServer(t *testing.T, supply uint64, holders []lpHolder) *rpc.Client {
+	tokenAccounts := make(map[string]lpHolder, len(holders))
+	programs := make(map[string]solana.PublicKey, len(holders))
+	var largest []interface{}
+	for _, holder := range holders {
+		address := solana.NewWallet().PublicKey()
+		tokenAccounts[address.String()] = holder
+		programs[holder.owner.String()] = holder.program
+		largest = append(largest, map[string]interface{}{
+			"address":        address.String(),
+			"amount":         strconv.FormatUint(holder.amount, 10),
+			"decimals":       9,
+			"uiAmountString": "0",
+		})
+	}
+
+	account := func(owner solana.PublicKey, data []byte) map[string]interface{} {
+		return map[string]interface{}{
+			"lamports":   1,
+			"owner":      owner.String(),
+			"data":       []string{base64.StdEncoding.EncodeToString(data), "base64"},
+			"executable": false,
+			"rentEpoch":  0,
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("bad request: %v", err)
+			return
+		}
+
+		var value interface{}
+		switch req.Method {
+		case "getTokenSupply":
+			value = map[string]interface{}{"amount": strconv.FormatUint(supply, 10), "decimals": 9, "uiAmountString": "0"}
+		case "getTokenLargestAccounts":
+			value = largest
+		case "getMultipleAccounts":
+			var addresses []string
+			json.Unmarshal(req.Params[0], &addresses)
+			accounts := make([]interface{}, len(addresses))
+			for i, address := range addresses {
+				if holder, ok := tokenAccounts[address]; ok {
+					data := make([]byte, 165)
+					copy(data[32:64], holder.owner[:])
+					accounts[i] = account(solana.TokenProgramID, data)
+				} else if program, ok := programs[address]; ok {
+					accounts[i] = account(program, nil)
+				}
+			}
+			value = accounts
+		default:
+			t.Errorf("unexpected method %s", req.Method)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  map[string]interface{}{"context": map[string]interface{}{"slot": 1}, "value": value},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return rpc.New(server.URL)
+}
+
+func TestGetLPLockInfo(t *testing.T) {
+	escrow := solana.NewWallet().PublicKey()
+	trader := solana.NewWallet().PublicKey()
+
+	tests := []struct {
+		name           string
+		lpAmount       uint64
+		supply         uint64
+		holders        []lpHolder
+		issued         uint64
+		burned, locked uint64
+		safePct        float64
+	}{
+		{
+			name:     "burned by supply reduction",
+			lpAmount: 1000,
+			supply:   400,
+			holders:  []lpHolder{{owner: trader, program: solana.SystemProgramID, amount: 400}},
+			issued:   1000, burned: 600, safePct: 60,
+		},
+		{
+			name:     "pool state short of the supply",
+			lpAmount: 990,
+			supply:   1000,
+			holders:  []lpHolder{{owner: trader, program: solana.SystemProgramID, amount: 1000}},
+			issued:   1000, safePct: 0,
+		},
+		{
+			name:     "sent to the incinerator",
+			lpAmount: 1000,
+			supply:   1000,
+			holders: []lpHolder{
+				{owner: IncineratorAddress, amount: 700},
+				{owner: trader, program: solana.SystemProgramID, amount: 300},
+			},
+			issued: 1000, burned: 700, safePct: 70,
+		},
+		{
+			name:     "locked in Streamflow",
+			lpAmount: 1000,
+			supply:   900,
+			holders: []lpHolder{
+				{owner: escrow, program: StreamflowProgram, amount: 500},
+				{owner: trader, program: solana.SystemProgramID, amount: 400},
+			},
+			issued: 1000, burned: 100, locked: 500, safePct: 60,
+		},
+		{
+			name:     "holders beyond the supply are not counted twice",
+			lpAmount: 1000,
+			supply:   400,
+			holders: []lpHolder{
+				{owner: IncineratorAddress, amount: 300},
+				{owner: escrow, program: StreamflowProgram, amount: 300},
+			},
+			issued: 1000, burned: 900, locked: 100, safePct: 100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := &PoolVaults{Program: RaydiumAMMV4Program, LPMint: solana.NewWallet().PublicKey(), lpAmount: tt.lpAmount}
+			info, err := GetLPLockInfo(context.Background(), lpServer(t, tt.supply, tt.holders), pool, "")
+			if err != nil {
+				t.Fatalf("GetLPLockInfo: %v", err)
+			}
+			if info.Issued != tt.issued || info.Burned != tt.burned || info.Locked != tt.locked {
+				t.Errorf("GetLPLockInfo = %d issued, %d burned, %d locked, want %d, %d, %d",
+					info.Issued, info.Burned, info.Locked, tt.issued, tt.burned, tt.locked)
+			}
+			if got := info.SafePct(); math.Abs(got-tt.safePct) > 1e-9 {
+				t.Errorf("SafePct = %v, want %v", got, tt.safePct)
+			}
+		})
+	}
+}
+
+func TestGetLPLockInfoWhirlpool(t *testing.T) {
+	pool := &PoolVaults{Program: OrcaWhirlpoolProgram}
+	if _, err := GetLPLockInfo(context.Background(), rpc.New("http://127.0.0.1:1"), pool, ""); err == nil {
+		t.Error("GetLPLockInfo on a Whirlpool = nil error, want one")
+	}
+}
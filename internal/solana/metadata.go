@@ -0,0 +1,112 @@
+package solana
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// TokenMetadata is the Metaplex metadata of a token
+type TokenMetadata struct {
+	Name            string
+	Symbol          string
+	URI             string
+	UpdateAuthority solana.PublicKey
+	IsMutable       bool
+}
+
+// HasLiveUpdateAuthority reports whether someone can still change the
+// metadata (name, symbol, image) after launch
+func (m *TokenMetadata) HasLiveUpdateAuthority() bool {
+	return m.IsMutable && !m.UpdateAuthority.IsZero() && !m.UpdateAuthority.Equals(solana.SystemProgramID)
+}
+
+// MetadataAddress derives the Metaplex metadata PDA of a mint
+func MetadataAddress(mint solana.PublicKey) (solana.PublicKey, error) {
+	addr, _, err := solana.FindTokenMetadataAddress(mint)
+	if err != nil {
+		return solana.PublicKey{}, fmt.Errorf("failed to derive metadata address: %w", err)
+	}
+	return addr, nil
+}
+
+// ParseTokenMetadata decodes a Metaplex metadata account
+func ParseTokenMetadata(data []byte) (*TokenMetadata, error) {
+	// Layout: key (1), update authority (32), mint (32), then borsh data:
+	// name, symbol, uri (u32 length + bytes each), seller fee bps (u16),
+	// creators (option<vec<34 bytes>>), primary sale happened (bool), is mutable (bool)
+	r := &borshReader{data: data, pos: 1}
+
+	meta := &TokenMetadata{}
+	meta.UpdateAuthority = r.publicKey()
+	r.skip(32) // mint
+	meta.Name = r.string()
+	meta.Symbol = r.string()
+	meta.URI = r.string()
+	r.skip(2) // seller fee basis points
+	if r.bool() {
+		creators := r.u32()
+		r.skip(int(creators) * 34) // address (32), verified (1), share (1)
+	}
+	r.bool() // primary sale happened
+	meta.IsMutable = r.bool()
+
+	if r.err != nil {
+		return nil, fmt.Errorf("invalid metadata account: %w", r.err)
+	}
+	return meta, nil
+}
+
+// borshReader reads the few borsh types the metadata layout needs; the first
+// out-of-bounds read sets err and every later read returns zero values
+type borshReader struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+func (r *borshReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || r.pos+n > len(r.data) {
+		r.err = fmt.Errorf("unexpected end of data at offset %d", r.pos)
+		return nil
+	}
+	b := r.data[r.pos : r.pos+n]
+	r.pos += n
+	return b
+}
+
+func (r *borshReader) skip(n int) {
+	r.next(n)
+}
+
+func (r *borshReader) bool() bool {
+	b := r.next(1)
+	return b != nil && b[0] != 0
+}
+
+func (r *borshReader) u32() uint32 {
+	b := r.next(4)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(b)
+}
+
+func (r *borshReader) publicKey() solana.PublicKey {
+	b := r.next(32)
+	if b == nil {
+		return solana.PublicKey{}
+	}
+	return solana.PublicKeyFromBytes(b)
+}
+
+// string reads a length-prefixed string; Metaplex pads fixed-size fields with NULs
+func (r *borshReader) string() string {
+	n := r.u32()
+	return strings.TrimRight(string(r.next(int(n))), "\x00 ")
+}
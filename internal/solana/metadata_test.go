@@ -0,0 +1,113 @@
+package solana
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// metadataAccount builds a Metaplex metadata account with the given creator count
+func metadataAccount(authority solana.PublicKey, name, symbol, uri string, creators int, isMutable bool) []byte {
+	data := []byte{4} // Key::MetadataV1
+	data = append(data, authority[:]...)
+	mint := solana.NewWallet().PublicKey()
+	data = append(data, mint[:]...)
+	for _, s := range []string{name, symbol, uri} {
+		data = binary.LittleEndian.AppendUint32(data, uint32(len(s)))
+		data = append(data, s...)
+	}
+	data = binary.LittleEndian.AppendUint16(data, 500) // Seller fee bps
+	if creators < 0 {
+		data = append(data, 0) // None
+	} else {
+		data = append(data, 1)
+		data = binary.LittleEndian.AppendUint32(data, uint32(creators))
+		for i := 0; i < creators; i++ {
+			creator := solana.NewWallet().PublicKey()
+			data = append(data, creator[:]...)
+			data = append(data, 1, 100) // Verified, all of the share
+		}
+	}
+	data = append(data, 1) // Primary sale happened
+	if isMutable {
+		data = append(data, 1)
+	} else {
+		data = append(data, 0)
+	}
+	return append(data, make([]byte, 64)...) // Trailing fields this parser ignores
+}
+
+func TestParseTokenMetadata(t *testing.T) {
+	authority := solana.NewWallet().PublicKey()
+
+	tests := []struct {
+		name        string
+		data        []byte
+		wantName    string
+		wantSymbol  string
+		wantMutable bool
+	}{
+		{"no creators", metadataAccount(authority, "Token", "TKN", "https://x", -1, true), "Token", "TKN", true},
+		{"with creators", metadataAccount(authority, "Token", "TKN", "https://x", 3, false), "Token", "TKN", false},
+		{"empty creator list", metadataAccount(authority, "Token", "TKN", "https://x", 0, true), "Token", "TKN", true},
+		{
+			"NUL padded fields",
+			metadataAccount(authority, "Padded"+strings.Repeat("\x00", 26), "PAD\x00\x00 ", "https://x"+strings.Repeat("\x00", 191), 1, true),
+			"Padded", "PAD", true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta, err := ParseTokenMetadata(tt.data)
+			if err != nil {
+				t.Fatalf("ParseTokenMetadata: %v", err)
+			}
+			if meta.Name != tt.wantName || meta.Symbol != tt.wantSymbol || meta.URI != "https://x" {
+				t.Errorf("ParseTokenMetadata = %q %q %q, want %q %q %q", meta.Name, meta.Symbol, meta.URI, tt.wantName, tt.wantSymbol, "https://x")
+			}
+			if meta.IsMutable != tt.wantMutable {
+				t.Errorf("IsMutable = %v, want %v", meta.IsMutable, tt.wantMutable)
+			}
+			if meta.UpdateAuthority != authority {
+				t.Errorf("UpdateAuthority = %s, want %s", meta.UpdateAuthority, authority)
+			}
+		})
+	}
+}
+
+func TestParseTokenMetadataTruncated(t *testing.T) {
+	full := metadataAccount(solana.NewWallet().PublicKey(), "Token", "TKN", "https://x", 2, true)
+	end := len(full) - 64 // Without the ignored trailing fields
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"inside the update authority", full[:20]},
+		{"inside the name", full[:1+32+32+4+2]},
+		{"inside the creators", full[:end-2-34]},
+		{"missing is mutable", full[:end-1]},
+	}
+	for _, tt := range tests {
+		if meta, err := ParseTokenMetadata(tt.data); err == nil {
+			t.Errorf("%s: ParseTokenMetadata = %+v, want an error", tt.name, meta)
+		}
+	}
+
+	// A creator count far beyond the data must not be trusted
+	huge := append([]byte(nil), full...)
+	creatorsAt := 1 + 32 + 32 + (4 + 5) + (4 + 3) + (4 + 9) + 2 + 1
+	binary.LittleEndian.PutUint32(huge[creatorsAt:], 1<<30)
+	if _, err := ParseTokenMetadata(huge); err == nil {
+		t.Error("ParseTokenMetadata accepted a creator count beyond the data")
+	}
+
+	// The parsed prefix is all it needs
+	if _, err := ParseTokenMetadata(full[:end]); err != nil {
+		t.Errorf("ParseTokenMetadata without trailing fields: %v", err)
+	}
+}
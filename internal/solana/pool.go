@@ -0,0 +1,338 @@
+package solana
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+var (
+	RaydiumAMMV4Program  = solana.MustPublicKeyFromBase58("675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8")
+	OrcaWhirlpoolProgram = solana.MustPublicKeyFromBase58("9W959DqEETiGZocYWCQPaJ6sBmUzgfxXfqGeTEdp3aQP")
+	WrappedSOLMint       = solana.MustPublicKeyFromBase58("So11111111111111111111111111111111111111112")
+	USDCMint             = solana.MustPublicKeyFromBase58("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")
+	USDTMint             = solana.MustPublicKeyFromBase58("Es9vMFrzaCERmJfrF4H2FYD4KCoNkY11McCe8BenwNYB")
+)
+
+// Raydium AMM V4 pool state layout (752 bytes)
+const (
+	raydiumAMMV4Size              = 752
+	raydiumBaseNeedTakePnlOffset  = 192
+	raydiumQuoteNeedTakePnlOffset = 200
+	raydiumBaseVaultOffset        = 336
+	raydiumQuoteVaultOffset       = 368
+	raydiumBaseMintOffset         = 400
+	raydiumQuoteMintOffset        = 432
+	raydiumLPMintOffset           = 464
+	raydiumLPAmountOffset         = 720
+)
+
+// Orca Whirlpool account layout (653 bytes, after the 8-byte discriminator)
+const (
+	whirlpoolSize            = 653
+	whirlpoolSqrtPriceOffset = 65 // u128, Q64.64
+	whirlpoolMintAOffset     = 101
+	whirlpoolVaultAOffset    = 133
+	whirlpoolMintBOffset     = 181
+	whirlpoolVaultBOffset    = 213
+)
+
+// PoolVaults identifies the token accounts holding a pool's reserves. Base and
+// quote are Raydium's names; for a Whirlpool they are token A and token B.
+type PoolVaults struct {
+	Pool       solana.PublicKey
+	Program    solana.PublicKey
+	BaseMint   solana.PublicKey
+	QuoteMint  solana.PublicKey
+	BaseVault  solana.PublicKey
+	QuoteVault solana.PublicKey
+	LPMint     solana.PublicKey // Raydium only; zero for Whirlpools
+
+	// Raydium keeps accrued protocol fees in the vaults until they are taken
+	baseNeedTakePnl  uint64
+	quoteNeedTakePnl uint64
+
+	// LP tokens the pool has issued and not redeemed (Raydium only)
+	lpAmount uint64
+
+	// Current price of token A in token B, both in base units (Whirlpool only)
+	whirlpoolPrice float64
+}
+
+// PoolReserves is a pool's token balances, valued in USD when one side is
+// SOL or a USD stablecoin
+type PoolReserves struct {
+	*PoolVaults
+	BaseReserve  uint64
+	QuoteReserve uint64
+	LiquidityUSD float64 // Both sides valued in USD at the pool price; 0 when neither side can be priced
+}
+
+// GetPoolVaults reads the reserve vaults of a Raydium AMM V4 or Orca Whirlpool pool
+func GetPoolVaults(ctx context.Context, client *rpc.Client, poolAddress string) (*PoolVaults, error) {
+	pool, err := solana.PublicKeyFromBase58(poolAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pool address: %w", err)
+	}
+
+	accountInfo, err := client.GetAccountInfo(ctx, pool)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pool account: %w", err)
+	}
+	if accountInfo == nil || accountInfo.Value == nil {
+		return nil, fmt.Errorf("pool account not found")
+	}
+
+	return ParsePool(pool, accountInfo.Value.Owner, accountInfo.Value.Data.GetBinary())
+}
+
+// ParsePool decodes a pool account owned by a supported AMM program
+func ParsePool(pool, owner solana.PublicKey, data []byte) (*PoolVaults, error) {
+	key := func(offset int) solana.PublicKey {
+		return solana.PublicKeyFromBytes(data[offset : offset+32])
+	}
+
+	switch {
+	case owner.Equals(RaydiumAMMV4Program):
+		if len(data) < raydiumAMMV4Size {
+			return nil, fmt.Errorf("invalid pool account data")
+		}
+		return &PoolVaults{
+			Pool:             pool,
+			Program:          owner,
+			BaseVault:        key(raydiumBaseVaultOffset),
+			QuoteVault:       key(raydiumQuoteVaultOffset),
+			BaseMint:         key(raydiumBaseMintOffset),
+			QuoteMint:        key(raydiumQuoteMintOffset),
+			LPMint:           key(raydiumLPMintOffset),
+			baseNeedTakePnl:  binary.LittleEndian.Uint64(data[raydiumBaseNeedTakePnlOffset:]),
+			quoteNeedTakePnl: binary.LittleEndian.Uint64(data[raydiumQuoteNeedTakePnlOffset:]),
+			lpAmount:         binary.LittleEndian.Uint64(data[raydiumLPAmountOffset:]),
+		}, nil
+
+	case owner.Equals(OrcaWhirlpoolProgram):
+		if len(data) < whirlpoolSize {
+			return nil, fmt.Errorf("invalid pool account data")
+		}
+		// sqrt_price is a Q64.64 fixed-point number
+		sqrtPrice := float64(binary.LittleEndian.Uint64(data[whirlpoolSqrtPriceOffset+8:])) +
+			float64(binary.LittleEndian.Uint64(data[whirlpoolSqrtPriceOffset:]))/math.Exp2(64)
+		return &PoolVaults{
+			Pool:           pool,
+			Program:        owner,
+			BaseMint:       key(whirlpoolMintAOffset),
+			BaseVault:      key(whirlpoolVaultAOffset),
+			QuoteMint:      key(whirlpoolMintBOffset),
+			QuoteVault:     key(whirlpoolVaultBOffset),
+			whirlpoolPrice: sqrtPrice * sqrtPrice,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported pool program: %s", owner)
+}
+
+// IsConstantProduct reports whether the price follows from the reserve ratio.
+// Whirlpools concentrate liquidity, so their vault balances don't give a price.
+func (p *PoolVaults) IsConstantProduct() bool {
+	return p.Program.Equals(RaydiumAMMV4Program)
+}
+
+// SOLSide returns the vault holding SOL and the vault holding the token.
+// ok is false when neither side of the pool is wrapped SOL.
+func (p *PoolVaults) SOLSide() (solVault, tokenVault solana.PublicKey, ok bool) {
+	switch {
+	case p.QuoteMint.Equals(WrappedSOLMint):
+		return p.QuoteVault, p.BaseVault, true
+	case p.BaseMint.Equals(WrappedSOLMint):
+		return p.BaseVault, p.QuoteVault, true
+	}
+	return solana.PublicKey{}, solana.PublicKey{}, false
+}
+
+// FindPools returns every Raydium AMM V4 and Orca Whirlpool pool that trades
+// the mint, on either side. This scans program accounts and can be slow on
+// public RPC endpoints.
+func FindPools(ctx context.Context, client *rpc.Client, mintAddress string) ([]*PoolVaults, error) {
+	mint, err := solana.PublicKeyFromBase58(mintAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mint address: %w", err)
+	}
+
+	searches := []struct {
+		program solana.PublicKey
+		size    uint64
+		offsets []uint64
+	}{
+		{RaydiumAMMV4Program, raydiumAMMV4Size, []uint64{raydiumBaseMintOffset, raydiumQuoteMintOffset}},
+		{OrcaWhirlpoolProgram, whirlpoolSize, []uint64{whirlpoolMintAOffset, whirlpoolMintBOffset}},
+	}
+
+	var pools []*PoolVaults
+	for _, search := range searches {
+		for _, offset := range search.offsets {
+			accounts, err := client.GetProgramAccountsWithOpts(ctx, search.program, &rpc.GetProgramAccountsOpts{
+				Encoding: solana.EncodingBase64,
+				Filters: []rpc.RPCFilter{
+					{DataSize: search.size},
+					{Memcmp: &rpc.RPCFilterMemcmp{Offset: offset, Bytes: solana.Base58(mint.Bytes())}},
+				},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to search pools: %w", err)
+			}
+
+			for _, account := range accounts {
+				if account.Account == nil || account.Account.Data == nil {
+					continue
+				}
+				pool, err := ParsePool(account.Pubkey, account.Account.Owner, account.Account.Data.GetBinary())
+				if err != nil {
+					continue
+				}
+				pools = append(pools, pool)
+			}
+		}
+	}
+
+	return pools, nil
+}
+
+// GetPoolReserves reads a pool's vault balances and values them in USD
+func GetPoolReserves(ctx context.Context, client *rpc.Client, pool *PoolVaults, solPriceUSD float64) (*PoolReserves, error) {
+	baseAmount, err := GetTokenAccountAmount(ctx, client, pool.BaseVault)
+	if err != nil {
+		return nil, err
+	}
+	quoteAmount, err := GetTokenAccountAmount(ctx, client, pool.QuoteVault)
+	if err != nil {
+		return nil, err
+	}
+
+	reserves := &PoolReserves{
+		PoolVaults:   pool,
+		BaseReserve:  saturatingSub(baseAmount, pool.baseNeedTakePnl),
+		QuoteReserve: saturatingSub(quoteAmount, pool.quoteNeedTakePnl),
+	}
+	reserves.LiquidityUSD = poolLiquidityUSD(pool, reserves.BaseReserve, reserves.QuoteReserve, solPriceUSD)
+
+	return reserves, nil
+}
+
+// poolLiquidityUSD values both reserves of a pool with one SOL or stablecoin side.
+// A constant-product pool holds equal value on both sides, so the total is
+// twice the priced side. A Whirlpool's concentrated liquidity doesn't, so its
+// token side is valued at the pool's current price instead.
+func poolLiquidityUSD(pool *PoolVaults, baseReserve, quoteReserve uint64, solPriceUSD float64) float64 {
+	quoteUSD, quotePriced := reserveValueUSD(pool.QuoteMint, quoteReserve, solPriceUSD)
+	baseUSD, basePriced := reserveValueUSD(pool.BaseMint, baseReserve, solPriceUSD)
+
+	if pool.IsConstantProduct() {
+		switch {
+		case quotePriced:
+			return 2 * quoteUSD
+		case basePriced:
+			return 2 * baseUSD
+		}
+		return 0
+	}
+
+	// USD per base unit of the priced side, times the other side converted
+	// into it at the pool price
+	switch {
+	case quotePriced:
+		quoteUnitUSD, _ := reserveValueUSD(pool.QuoteMint, 1, solPriceUSD)
+		return quoteUSD + float64(baseReserve)*pool.whirlpoolPrice*quoteUnitUSD
+	case basePriced:
+		if pool.whirlpoolPrice <= 0 {
+			return baseUSD
+		}
+		baseUnitUSD, _ := reserveValueUSD(pool.BaseMint, 1, solPriceUSD)
+		return baseUSD + float64(quoteReserve)/pool.whirlpoolPrice*baseUnitUSD
+	}
+	return 0
+}
+
+// GetPoolLiquidity finds the deepest priced pool for a token and returns its
+// USD liquidity. Pass a known pool address to skip the pool search.
+func GetPoolLiquidity(ctx context.Context, client *rpc.Client, mint, poolAddress string, solPriceUSD float64) (*PoolReserves, error) {
+	var pools []*PoolVaults
+	if poolAddress != "" {
+		pool, err := GetPoolVaults(ctx, client, poolAddress)
+		if err != nil {
+			return nil, err
+		}
+		pools = append(pools, pool)
+	} else {
+		found, err := FindPools(ctx, client, mint)
+		if err != nil {
+			return nil, err
+		}
+		pools = found
+	}
+
+	var best *PoolReserves
+	for _, pool := range pools {
+		reserves, err := GetPoolReserves(ctx, client, pool, solPriceUSD)
+		if err != nil {
+			continue
+		}
+		if best == nil || reserves.LiquidityUSD > best.LiquidityUSD {
+			best = reserves
+		}
+	}
+
+	if best == nil {
+		return nil, fmt.Errorf("no pool found for %s", mint)
+	}
+	return best, nil
+}
+
+// reserveValueUSD values a reserve of SOL or a USD stablecoin
+func reserveValueUSD(mint solana.PublicKey, amount uint64, solPriceUSD float64) (float64, bool) {
+	switch {
+	case mint.Equals(WrappedSOLMint):
+		return ConvertLamportsToSOL(amount) * solPriceUSD, true
+	case mint.Equals(USDCMint), mint.Equals(USDTMint):
+		return ToUIAmount(amount, 6), true
+	}
+	return 0, false
+}
+
+func saturatingSub(a, b uint64) uint64 {
+	if b > a {
+		return 0
+	}
+	return a - b
+}
+
+// GetTokenAccountAmount fetches the balance of an SPL token account in base units
+func GetTokenAccountAmount(ctx context.Context, client *rpc.Client, account solana.PublicKey) (uint64, error) {
+	info, err := client.GetAccountInfo(ctx, account)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get token account: %w", err)
+	}
+	if info == nil || info.Value == nil {
+		return 0, fmt.Errorf("token account not found")
+	}
+	return ParseTokenAccountAmount(info.Value.Data.GetBinary())
+}
+
+// ParseTokenAccountAmount reads the amount field of an SPL token account
+func ParseTokenAccountAmount(data []byte) (uint64, error) {
+	if len(data) < 72 {
+		return 0, fmt.Errorf("invalid token account data")
+	}
+	return binary.LittleEndian.Uint64(data[64:72]), nil
+}
+
+// PriceFromReserves returns the token price in SOL implied by pool reserves
+func PriceFromReserves(solReserve, tokenReserve uint64, tokenDecimals uint8) float64 {
+	if tokenReserve == 0 {
+		return 0
+	}
+	return ConvertLamportsToSOL(solReserve) / ToUIAmount(tokenReserve, tokenDecimals)
+}
@@ -0,0 +1,161 @@
+package solana
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func TestParsePool(t *testing.T) {
+	pool := solana.NewWallet().PublicKey()
+	token := solana.NewWallet().PublicKey()
+	tokenVault := solana.NewWallet().PublicKey()
+	solVault := solana.NewWallet().PublicKey()
+
+	t.Run("raydium", func(t *testing.T) {
+		data := make([]byte, raydiumAMMV4Size)
+		copy(data[raydiumBaseVaultOffset:], tokenVault[:])
+		copy(data[raydiumQuoteVaultOffset:], solVault[:])
+		copy(data[raydiumBaseMintOffset:], token[:])
+		copy(data[raydiumQuoteMintOffset:], WrappedSOLMint[:])
+
+		vaults, err := ParsePool(pool, RaydiumAMMV4Program, data)
+		if err != nil {
+			t.Fatalf("ParsePool: %v", err)
+		}
+		if vaults.BaseMint != token || vaults.QuoteMint != WrappedSOLMint || vaults.BaseVault != tokenVault || vaults.QuoteVault != solVault {
+			t.Errorf("ParsePool = %+v", vaults)
+		}
+		if !vaults.IsConstantProduct() {
+			t.Error("Raydium AMM V4 pool is not constant product")
+		}
+		gotSOL, gotToken, ok := vaults.SOLSide()
+		if !ok || gotSOL != solVault || gotToken != tokenVault {
+			t.Errorf("SOLSide = %s, %s, %v, want the quote vault as SOL", gotSOL, gotToken, ok)
+		}
+	})
+
+	t.Run("whirlpool", func(t *testing.T) {
+		data := make([]byte, whirlpoolSize)
+		copy(data[whirlpoolMintAOffset:], WrappedSOLMint[:])
+		copy(data[whirlpoolVaultAOffset:], solVault[:])
+		copy(data[whirlpoolMintBOffset:], token[:])
+		copy(data[whirlpoolVaultBOffset:], tokenVault[:])
+		binary.LittleEndian.PutUint64(data[whirlpoolSqrtPriceOffset:], 1<<63) // sqrt_price 1.5 in Q64.64
+		binary.LittleEndian.PutUint64(data[whirlpoolSqrtPriceOffset+8:], 1)
+
+		vaults, err := ParsePool(pool, OrcaWhirlpoolProgram, data)
+		if err != nil {
+			t.Fatalf("ParsePool: %v", err)
+		}
+		if vaults.BaseMint != WrappedSOLMint || vaults.QuoteMint != token || vaults.BaseVault != solVault || vaults.QuoteVault != tokenVault {
+			t.Errorf("ParsePool = %+v", vaults)
+		}
+		if vaults.IsConstantProduct() {
+			t.Error("Whirlpool treated as constant product")
+		}
+		if vaults.whirlpoolPrice != 2.25 {
+			t.Errorf("whirlpool price = %v, want 2.25", vaults.whirlpoolPrice)
+		}
+		gotSOL, gotToken, ok := vaults.SOLSide()
+		if !ok || gotSOL != solVault || gotToken != tokenVault {
+			t.Errorf("SOLSide = %s, %s, %v, want token A's vault as SOL", gotSOL, gotToken, ok)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		if _, err := ParsePool(pool, RaydiumAMMV4Program, make([]byte, raydiumAMMV4Size-1)); err == nil {
+			t.Error("ParsePool accepted a short Raydium account")
+		}
+		if _, err := ParsePool(pool, OrcaWhirlpoolProgram, make([]byte, whirlpoolSize-1)); err == nil {
+			t.Error("ParsePool accepted a short Whirlpool account")
+		}
+		if _, err := ParsePool(pool, solana.TokenProgramID, make([]byte, raydiumAMMV4Size)); err == nil {
+			t.Error("ParsePool accepted an unsupported program")
+		}
+	})
+}
+
+func TestSOLSideWithoutSOL(t *testing.T) {
+	vaults := &PoolVaults{BaseMint: solana.NewWallet().PublicKey(), QuoteMint: USDCMint}
+	if _, _, ok := vaults.SOLSide(); ok {
+		t.Error("SOLSide found SOL in a token/USDC pool")
+	}
+}
+
+func TestReserveValueUSD(t *testing.T) {
+	tests := []struct {
+		mint   solana.PublicKey
+		amount uint64
+		want   float64
+		ok     bool
+	}{
+		{WrappedSOLMint, 2_000_000_000, 300, true}, // 2 SOL at $150
+		{USDCMint, 1_500_000, 1.5, true},
+		{USDTMint, 250_000_000, 250, true},
+		{solana.NewWallet().PublicKey(), 1_000, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := reserveValueUSD(tt.mint, tt.amount, 150)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("reserveValueUSD(%s, %d) = %v, %v, want %v, %v", tt.mint, tt.amount, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestPoolLiquidityUSD(t *testing.T) {
+	token := solana.NewWallet().PublicKey()
+	tests := []struct {
+		name                      string
+		pool                      *PoolVaults
+		baseReserve, quoteReserve uint64
+		want                      float64
+	}{
+		{
+			name:         "constant product doubles the priced side",
+			pool:         &PoolVaults{Program: RaydiumAMMV4Program, BaseMint: token, QuoteMint: WrappedSOLMint},
+			baseReserve:  1_000_000,
+			quoteReserve: 10_000_000_000, // 10 SOL
+			want:         3000,
+		},
+		{
+			name: "whirlpool values the token side at the pool price",
+			// 4 token base units per lamport, so 8e9 tokens are worth 2 SOL
+			pool:         &PoolVaults{Program: OrcaWhirlpoolProgram, BaseMint: WrappedSOLMint, QuoteMint: token, whirlpoolPrice: 4},
+			baseReserve:  1_000_000_000,
+			quoteReserve: 8_000_000_000,
+			want:         450,
+		},
+		{
+			name: "whirlpool priced in a stablecoin",
+			// 0.25 USDC base units per token base unit
+			pool:         &PoolVaults{Program: OrcaWhirlpoolProgram, BaseMint: token, QuoteMint: USDCMint, whirlpoolPrice: 0.25},
+			baseReserve:  1_000_000_000,
+			quoteReserve: 100_000_000,
+			want:         350,
+		},
+		{
+			name:         "one-sided whirlpool",
+			pool:         &PoolVaults{Program: OrcaWhirlpoolProgram, BaseMint: token, QuoteMint: WrappedSOLMint, whirlpoolPrice: 0.5},
+			quoteReserve: 1_000_000_000,
+			want:         150,
+		},
+		{
+			name:         "nothing priced",
+			pool:         &PoolVaults{Program: RaydiumAMMV4Program, BaseMint: token, QuoteMint: solana.NewWallet().PublicKey()},
+			baseReserve:  1_000,
+			quoteReserve: 1_000,
+			want:         0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := poolLiquidityUSD(tt.pool, tt.baseReserve, tt.quoteReserve, 150); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("poolLiquidityUSD = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
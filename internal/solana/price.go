@@ -2,10 +2,7 @@ package solana
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"time"
 )
 
@@ -65,6 +62,25 @@ func ShouldStopLoss(entryPrice, currentPrice, stopLossPct float64) bool {
 	return pnlPct <= -stopLossPct
 }
 
+// ShouldTrailingStop checks if price has dropped trailPct below the high-water mark
+func ShouldTrailingStop(highWaterPrice, currentPrice, trailPct float64) bool {
+	if highWaterPrice == 0 || trailPct <= 0 {
+		return false
+	}
+	_, dropPct := CalculatePnL(highWaterPrice, currentPrice, 1)
+	return dropPct <= -trailPct
+}
+
+// ShouldBreakevenStop checks if a position that once gained triggerPct has
+// fallen back to its entry price
+func ShouldBreakevenStop(entryPrice, highWaterPrice, currentPrice, triggerPct float64) bool {
+	if entryPrice == 0 || triggerPct <= 0 {
+		return false
+	}
+	_, peakPct := CalculatePnL(entryPrice, highWaterPrice, 1)
+	return peakPct >= triggerPct && currentPrice <= entryPrice
+}
+
 // JupiterPriceResponse represents Jupiter Price API response
 type JupiterPriceResponse struct {
 	Data map[string]struct {
@@ -77,123 +93,40 @@ type JupiterPriceResponse struct {
 	TimeTaken float64 `json:"timeTaken"`
 }
 
-// GetPrice fetches price from Jupiter Price API
-func (j *JupiterClient) GetPrice(ctx context.Context, mint string) (float64, error) {
-	// Try Jupiter Price API first
-	priceURL := fmt.Sprintf("https://price.jup.ag/v4/price?ids=%s", mint)
-	
-	req, err := http.NewRequestWithContext(ctx, "GET", priceURL, nil)
-	if err != nil {
-		return 0, fmt.Errorf("failed to create request: %w", err)
-	}
-	
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		// Fallback to quote method
-		return j.getPriceViaQuote(ctx, mint)
-	}
-	defer resp.Body.Close()
-	
-	if resp.StatusCode != http.StatusOK {
-		return j.getPriceViaQuote(ctx, mint)
-	}
-	
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return j.getPriceViaQuote(ctx, mint)
-	}
-	
-	var priceResp JupiterPriceResponse
-	if err := json.Unmarshal(body, &priceResp); err != nil {
-		return j.getPriceViaQuote(ctx, mint)
-	}
-	
-	if data, ok := priceResp.Data[mint]; ok {
-		// Price is in USD already
-		return data.Price, nil
-	}
-	
-	// Fallback to quote
-	return j.getPriceViaQuote(ctx, mint)
-}
-
 // getPriceViaQuote estimates price using Jupiter quote (fallback)
-func (j *JupiterClient) getPriceViaQuote(ctx context.Context, mint string) (float64, error) {
+func (j *JupiterClient) getPriceViaQuote(ctx context.Context, mint string, decimals uint8) (float64, error) {
 	baseMint := "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v" // USDC
-	
+	const usdcDecimals = 6
+
 	quote, err := j.GetQuote(ctx, QuoteRequest{
 		InputMint:   mint,
 		OutputMint:  baseMint,
-		Amount:      1_000_000, // 1 token (6 decimals)
+		Amount:      ToRawAmount(1, decimals), // 1 whole token
 		SlippageBps: 100,
 	})
 	if err != nil {
 		return 0, fmt.Errorf("failed to get quote: %w", err)
 	}
-	
+
 	var inAmount, outAmount uint64
 	fmt.Sscanf(quote.InAmount, "%d", &inAmount)
 	fmt.Sscanf(quote.OutAmount, "%d", &outAmount)
-	
+
 	if inAmount == 0 {
 		return 0, fmt.Errorf("invalid quote amounts")
 	}
-	
-	priceUSD := float64(outAmount) / float64(inAmount)
-	return priceUSD, nil
-}
 
-// GetPoolLiquidity estimates pool liquidity for a token
-func GetPoolLiquidity(ctx context.Context, mint string) (float64, error) {
-	// TODO: Query Raydium/Orca pool accounts
-	// Parse pool reserves
-	// Calculate total liquidity in USD
-	return 0, fmt.Errorf("not implemented")
+	priceUSD := ToUIAmount(outAmount, usdcDecimals) / ToUIAmount(inAmount, decimals)
+	return priceUSD, nil
 }
 
-// ParsePoolReserves parses pool account data to get reserves
-func ParsePoolReserves(data []byte) (reserve0, reserve1 uint64, err error) {
-	// TODO: Parse Raydium or Orca pool data structure
-	return 0, 0, fmt.Errorf("not implemented")
-}
+// defaultPrices backs the package-level SOL price helpers so they share one
+// HTTP client and cache
+var defaultPrices = NewPriceService(nil, DefaultPriceTTL)
 
-// GetSOLPrice fetches current SOL/USD price from Jupiter
+// GetSOLPrice fetches current SOL/USD price from Jupiter (cached)
 func GetSOLPrice(ctx context.Context) (float64, error) {
-	priceURL := "https://price.jup.ag/v4/price?ids=So11111111111111111111111111111111111111112"
-	
-	req, err := http.NewRequestWithContext(ctx, "GET", priceURL, nil)
-	if err != nil {
-		return 100.0, fmt.Errorf("failed to create request: %w", err)
-	}
-	
-	client := &http.Client{Timeout: 5 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		// Return approximate fallback
-		return 100.0, nil
-	}
-	defer resp.Body.Close()
-	
-	if resp.StatusCode != http.StatusOK {
-		return 100.0, nil
-	}
-	
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return 100.0, nil
-	}
-	
-	var priceResp JupiterPriceResponse
-	if err := json.Unmarshal(body, &priceResp); err != nil {
-		return 100.0, nil
-	}
-	
-	if data, ok := priceResp.Data["So11111111111111111111111111111111111111112"]; ok {
-		return data.Price, nil
-	}
-	
-	return 100.0, nil
+	return defaultPrices.SOLPrice(ctx).USD, nil
 }
 
 // ConvertSOLToUSD converts SOL amount to USD using current price
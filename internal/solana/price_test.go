@@ -0,0 +1,46 @@
+package solana
+
+import "testing"
+
+func TestShouldTrailingStop(t *testing.T) {
+	tests := []struct {
+		name                      string
+		highWater, current, trail float64
+		want                      bool
+	}{
+		{"above the trail", 2.0, 1.9, 10, false},
+		{"exactly at the trail", 2.0, 1.5, 25, true},
+		{"below the trail", 2.0, 1.0, 10, true},
+		{"off", 2.0, 1.0, 0, false},
+		{"no high-water mark", 0, 1.0, 10, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ShouldTrailingStop(tt.highWater, tt.current, tt.trail); got != tt.want {
+				t.Errorf("ShouldTrailingStop(%v, %v, %v) = %v, want %v", tt.highWater, tt.current, tt.trail, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShouldBreakevenStop(t *testing.T) {
+	tests := []struct {
+		name                               string
+		entry, highWater, current, trigger float64
+		want                               bool
+	}{
+		{"never reached the trigger", 1.0, 1.1, 1.0, 20, false},
+		{"reached the trigger, still in profit", 1.0, 1.3, 1.1, 20, false},
+		{"reached the trigger, back at entry", 1.0, 1.3, 1.0, 20, true},
+		{"reached the trigger, below entry", 1.0, 1.25, 0.9, 20, true},
+		{"off", 1.0, 2.0, 0.5, 0, false},
+		{"no entry price", 0, 2.0, 0.5, 20, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ShouldBreakevenStop(tt.entry, tt.highWater, tt.current, tt.trigger); got != tt.want {
+				t.Errorf("ShouldBreakevenStop(%v, %v, %v, %v) = %v, want %v", tt.entry, tt.highWater, tt.current, tt.trigger, got, tt.want)
+			}
+		})
+	}
+}
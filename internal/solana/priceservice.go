@@ -0,0 +1,207 @@
+package solana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	priceAPIURL         = "https://price.jup.ag/v4/price"
+	priceBatchSize      = 100 // Max ids per Jupiter price request
+	DefaultPriceTTL     = 3 * time.Second
+	solPriceTTL         = 30 * time.Second
+	fallbackSOLPriceUSD = 100.0
+)
+
+// Price is a USD price with the time it was fetched
+type Price struct {
+	Mint      string    `json:"mint"`
+	USD       float64   `json:"usd"`
+	FetchedAt time.Time `json:"fetched_at"`
+	Source    string    `json:"source"` // "jupiter", "quote" or "fallback"
+}
+
+// Age is how long ago the price was fetched
+func (p Price) Age() time.Duration {
+	return time.Since(p.FetchedAt)
+}
+
+// Stale reports whether the price is older than maxAge
+func (p Price) Stale(maxAge time.Duration) bool {
+	return p.FetchedAt.IsZero() || p.Age() > maxAge
+}
+
+// PriceService is a shared price source that batches many mints into one
+// request and caches results for a TTL
+type PriceService struct {
+	jupiter    *JupiterClient
+	httpClient *http.Client
+	ttl        time.Duration
+
+	mu    sync.Mutex
+	cache map[string]Price
+}
+
+func NewPriceService(jupiter *JupiterClient, ttl time.Duration) *PriceService {
+	return &PriceService{
+		jupiter:    jupiter,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		ttl:        ttl,
+		cache:      make(map[string]Price),
+	}
+}
+
+// Cached returns the last known price without any network call
+func (s *PriceService) Cached(mint string) (Price, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.cache[mint]
+	return p, ok
+}
+
+// GetPrice returns a fresh price for one mint. Decimals are only needed for
+// the quote-based fallback.
+func (s *PriceService) GetPrice(ctx context.Context, mint string, decimals uint8) (Price, error) {
+	prices, err := s.GetPrices(ctx, map[string]uint8{mint: decimals})
+	if err != nil {
+		return Price{}, err
+	}
+	p, ok := prices[mint]
+	if !ok {
+		return Price{}, fmt.Errorf("no price for %s", mint)
+	}
+	return p, nil
+}
+
+// GetPrices returns prices for all mints (mint -> decimals), fetching only the
+// ones missing from the cache or older than the TTL, in batched requests.
+// Mints the price API doesn't know are priced with a Jupiter quote.
+func (s *PriceService) GetPrices(ctx context.Context, mints map[string]uint8) (map[string]Price, error) {
+	result := make(map[string]Price, len(mints))
+	var missing []string
+
+	s.mu.Lock()
+	for mint := range mints {
+		if p, ok := s.cache[mint]; ok && !p.Stale(s.ttl) {
+			result[mint] = p
+		} else {
+			missing = append(missing, mint)
+		}
+	}
+	s.mu.Unlock()
+
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	// Whatever the price API can't answer (or everything, if it is down)
+	// falls back to a quote
+	fetched, _ := s.fetchBatch(ctx, missing)
+
+	now := time.Now()
+	var lastErr error
+	for _, mint := range missing {
+		p := Price{Mint: mint, FetchedAt: now, Source: "jupiter"}
+		if usd, ok := fetched[mint]; ok && usd > 0 {
+			p.USD = usd
+		} else if s.jupiter == nil {
+			lastErr = fmt.Errorf("no price for %s", mint)
+			continue
+		} else {
+			usd, err := s.jupiter.getPriceViaQuote(ctx, mint, mints[mint])
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			p.USD = usd
+			p.Source = "quote"
+		}
+		result[mint] = p
+
+		s.mu.Lock()
+		s.cache[mint] = p
+		s.mu.Unlock()
+	}
+
+	if len(result) == 0 && lastErr != nil {
+		return nil, fmt.Errorf("failed to get prices: %w", lastErr)
+	}
+	return result, nil
+}
+
+// SOLPrice returns SOL/USD. When the API is unreachable it serves the last
+// known price (check Age), or a $100 fallback if there has never been one.
+func (s *PriceService) SOLPrice(ctx context.Context) Price {
+	mint := WrappedSOLMint.String()
+
+	s.mu.Lock()
+	cached, ok := s.cache[mint]
+	s.mu.Unlock()
+	if ok && !cached.Stale(solPriceTTL) {
+		return cached
+	}
+
+	fetched, err := s.fetchBatch(ctx, []string{mint})
+	if usd, found := fetched[mint]; err == nil && found && usd > 0 {
+		p := Price{Mint: mint, USD: usd, FetchedAt: time.Now(), Source: "jupiter"}
+		s.mu.Lock()
+		s.cache[mint] = p
+		s.mu.Unlock()
+		return p
+	}
+
+	if ok {
+		return cached
+	}
+	return Price{Mint: mint, USD: fallbackSOLPriceUSD, Source: "fallback"}
+}
+
+// fetchBatch queries the Jupiter price API for up to priceBatchSize mints per request
+func (s *PriceService) fetchBatch(ctx context.Context, mints []string) (map[string]float64, error) {
+	prices := make(map[string]float64, len(mints))
+
+	for start := 0; start < len(mints); start += priceBatchSize {
+		end := start + priceBatchSize
+		if end > len(mints) {
+			end = len(mints)
+		}
+
+		params := url.Values{}
+		params.Set("ids", strings.Join(mints[start:end], ","))
+
+		req, err := http.NewRequestWithContext(ctx, "GET", priceAPIURL+"?"+params.Encode(), nil)
+		if err != nil {
+			return prices, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		resp, err := s.httpClient.Do(req)
+		if err != nil {
+			return prices, fmt.Errorf("failed to fetch prices: %w", err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return prices, fmt.Errorf("failed to read response: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return prices, fmt.Errorf("price API error (status %d): %s", resp.StatusCode, string(body))
+		}
+
+		var priceResp JupiterPriceResponse
+		if err := json.Unmarshal(body, &priceResp); err != nil {
+			return prices, fmt.Errorf("failed to parse response: %w", err)
+		}
+		for id, data := range priceResp.Data {
+			prices[id] = data.Price
+		}
+	}
+
+	return prices, nil
+}
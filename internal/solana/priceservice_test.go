@@ -0,0 +1,192 @@
+package solana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakePriceAPI answers the Jupiter price API for the mints in prices and
+// quotes every other mint at quoteUSD per whole token
+type fakePriceAPI struct {
+	mu       sync.Mutex
+	prices   map[string]float64
+	quoteUSD float64
+	down     bool
+	batches  []int // Ids per price request
+	quotes   int
+}
+
+// redirectTransport sends every request to the test server, whatever its host
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (t redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func (f *fakePriceAPI) serve(t *testing.T, ttl time.Duration) *PriceService {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/price"):
+			if f.down {
+				http.Error(w, "unavailable", http.StatusServiceUnavailable)
+				return
+			}
+			ids := strings.Split(r.URL.Query().Get("ids"), ",")
+			f.batches = append(f.batches, len(ids))
+			data := make(map[string]interface{})
+			for _, id := range ids {
+				if usd, ok := f.prices[id]; ok {
+					data[id] = map[string]interface{}{"id": id, "price": usd}
+				}
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+		case strings.HasSuffix(r.URL.Path, "/quote"):
+			f.quotes++
+			// 1 whole token (6 decimals) for quoteUSD in USDC (6 decimals)
+			json.NewEncoder(w).Encode(QuoteResponse{
+				InAmount:  "1000000",
+				OutAmount: fmt.Sprintf("%d", int64(f.quoteUSD*1e6)),
+			})
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	target, _ := url.Parse(server.URL)
+	service := NewPriceService(NewJupiterClient(server.URL), ttl)
+	service.httpClient = &http.Client{Transport: redirectTransport{target: target}}
+	return service
+}
+
+func TestPriceServiceBatching(t *testing.T) {
+	ctx := context.Background()
+	api := &fakePriceAPI{prices: make(map[string]float64), quoteUSD: 0.5}
+	service := api.serve(t, time.Minute)
+
+	mints := make(map[string]uint8)
+	for i := 0; i < 150; i++ {
+		mint := fmt.Sprintf("mint%d", i)
+		mints[mint] = 6
+		api.prices[mint] = float64(i + 1)
+	}
+	mints["unlisted"] = 6 // Unknown to the price API, so priced by a quote
+
+	prices, err := service.GetPrices(ctx, mints)
+	if err != nil {
+		t.Fatalf("GetPrices: %v", err)
+	}
+	if len(prices) != 151 || prices["mint42"].USD != 43 || prices["mint42"].Source != "jupiter" {
+		t.Errorf("GetPrices returned %d prices, mint42 = %+v", len(prices), prices["mint42"])
+	}
+	if p := prices["unlisted"]; p.USD != 0.5 || p.Source != "quote" {
+		t.Errorf("unlisted = %+v, want 0.5 from a quote", p)
+	}
+	if len(api.batches) != 2 || api.batches[0]+api.batches[1] != 151 || api.batches[0] > priceBatchSize || api.batches[1] > priceBatchSize {
+		t.Errorf("price requests = %v, want 151 ids split across two", api.batches)
+	}
+	if api.quotes != 1 {
+		t.Errorf("quotes = %d, want 1", api.quotes)
+	}
+
+	// Everything is cached now
+	if _, err := service.GetPrices(ctx, mints); err != nil {
+		t.Fatalf("GetPrices: %v", err)
+	}
+	if len(api.batches) != 2 || api.quotes != 1 {
+		t.Errorf("cached GetPrices made %d price requests and %d quotes, want none", len(api.batches)-2, api.quotes-1)
+	}
+}
+
+func TestPriceServiceTTL(t *testing.T) {
+	ctx := context.Background()
+	api := &fakePriceAPI{prices: map[string]float64{"fresh": 1, "stale": 2}}
+	service := api.serve(t, time.Minute)
+
+	if _, err := service.GetPrices(ctx, map[string]uint8{"fresh": 6, "stale": 6}); err != nil {
+		t.Fatalf("GetPrices: %v", err)
+	}
+
+	// Age one entry past the TTL; only it is fetched again
+	service.mu.Lock()
+	aged := service.cache["stale"]
+	aged.FetchedAt = time.Now().Add(-2 * time.Minute)
+	service.cache["stale"] = aged
+	service.mu.Unlock()
+	api.prices["stale"] = 3
+
+	prices, err := service.GetPrices(ctx, map[string]uint8{"fresh": 6, "stale": 6})
+	if err != nil {
+		t.Fatalf("GetPrices: %v", err)
+	}
+	if len(api.batches) != 2 || api.batches[1] != 1 {
+		t.Errorf("price requests = %v, want a second one for the stale mint only", api.batches)
+	}
+	if prices["stale"].USD != 3 || prices["fresh"].USD != 1 {
+		t.Errorf("prices = %+v, want stale refreshed to 3 and fresh kept at 1", prices)
+	}
+	if cached, ok := service.Cached("stale"); !ok || cached.USD != 3 || cached.Stale(time.Minute) {
+		t.Errorf("Cached(stale) = %+v, %v, want a fresh 3", cached, ok)
+	}
+}
+
+func TestPriceStale(t *testing.T) {
+	tests := []struct {
+		name  string
+		price Price
+		want  bool
+	}{
+		{"never fetched", Price{}, true},
+		{"fresh", Price{FetchedAt: time.Now()}, false},
+		{"older than the max age", Price{FetchedAt: time.Now().Add(-time.Minute)}, true},
+	}
+	for _, tt := range tests {
+		if got := tt.price.Stale(30 * time.Second); got != tt.want {
+			t.Errorf("%s: Stale = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSOLPriceWhenTheAPIIsDown(t *testing.T) {
+	ctx := context.Background()
+	api := &fakePriceAPI{down: true}
+	service := api.serve(t, time.Minute)
+
+	// Never priced: the fixed fallback
+	if p := service.SOLPrice(ctx); p.USD != fallbackSOLPriceUSD || p.Source != "fallback" {
+		t.Errorf("SOLPrice = %+v, want the fallback", p)
+	}
+
+	// Priced before: the last known price, however old
+	fetchedAt := time.Now().Add(-time.Hour)
+	service.cache[WrappedSOLMint.String()] = Price{Mint: WrappedSOLMint.String(), USD: 150, FetchedAt: fetchedAt, Source: "jupiter"}
+	p := service.SOLPrice(ctx)
+	if p.USD != 150 || !p.FetchedAt.Equal(fetchedAt) || !p.Stale(solPriceTTL) {
+		t.Errorf("SOLPrice = %+v, want the stale 150", p)
+	}
+
+	// Back up: a fresh price
+	api.mu.Lock()
+	api.down = false
+	api.prices = map[string]float64{WrappedSOLMint.String(): 160}
+	api.mu.Unlock()
+	if p := service.SOLPrice(ctx); p.USD != 160 || p.Stale(solPriceTTL) {
+		t.Errorf("SOLPrice = %+v, want a fresh 160", p)
+	}
+}
@@ -2,7 +2,9 @@ package solana
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
+	"math"
 
 	"github.com/gagliardetto/solana-go"
 	"github.com/gagliardetto/solana-go/rpc"
@@ -11,6 +13,7 @@ import (
 // TokenInfo represents information about a SPL token
 type TokenInfo struct {
 	Mint              string
+	Program           solana.PublicKey // SPL Token or Token-2022 program that owns the mint
 	Name              string
 	Symbol            string
 	Decimals          uint8
@@ -19,6 +22,13 @@ type TokenInfo struct {
 	HasMintAuthority   bool
 	FreezeAuthority    *solana.PublicKey
 	MintAuthority      *solana.PublicKey
+	Metadata           *TokenMetadata // nil when the token has no Metaplex or Token-2022 metadata
+	Extensions         MintExtensions // Token-2022 extensions, zero for legacy mints
+}
+
+// IsToken2022 reports whether the mint belongs to the Token-2022 program
+func (t *TokenInfo) IsToken2022() bool {
+	return t.Program.Equals(solana.Token2022ProgramID)
 }
 
 // TokenAccountInfo represents holder information
@@ -35,31 +45,75 @@ func GetTokenInfo(ctx context.Context, client *rpc.Client, mintAddress string) (
 		return nil, fmt.Errorf("invalid mint address: %w", err)
 	}
 
-	// Get mint account info
-	accountInfo, err := client.GetAccountInfo(ctx, mint)
+	metadataAddr, err := MetadataAddress(mint)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get mint and metadata accounts in one request
+	accounts, err := client.GetMultipleAccounts(ctx, mint, metadataAddr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get mint account: %w", err)
 	}
 
-	if accountInfo == nil || accountInfo.Value == nil {
+	if accounts == nil || len(accounts.Value) < 2 || accounts.Value[0] == nil {
 		return nil, fmt.Errorf("mint account not found")
 	}
 
-	data := accountInfo.Value.Data.GetBinary()
+	mintAccount := accounts.Value[0]
+	if !mintAccount.Owner.Equals(solana.TokenProgramID) && !mintAccount.Owner.Equals(solana.Token2022ProgramID) {
+		return nil, fmt.Errorf("account is not a token mint (owner %s)", mintAccount.Owner)
+	}
+
+	info, err := parseMintAccount(mintAddress, mintAccount.Owner, mintAccount.Data.GetBinary())
+	if err != nil {
+		return nil, err
+	}
+
+	// Metaplex metadata wins; Token-2022 mints may carry their own instead
+	if account := accounts.Value[1]; account != nil && account.Owner.Equals(solana.TokenMetadataProgramID) {
+		if meta, err := ParseTokenMetadata(account.Data.GetBinary()); err == nil {
+			info.Metadata = meta
+		}
+	}
+	if info.Metadata == nil {
+		info.Metadata = info.Extensions.Metadata
+	}
+
+	info.Name = "Unknown"
+	info.Symbol = "UNKNOWN"
+	if meta := info.Metadata; meta != nil {
+		if meta.Name != "" {
+			info.Name = meta.Name
+		}
+		if meta.Symbol != "" {
+			info.Symbol = meta.Symbol
+		}
+	}
+
+	return info, nil
+}
+
+// parseMintAccount decodes the base mint layout, and the extensions of a
+// Token-2022 mint
+func parseMintAccount(mintAddress string, program solana.PublicKey, data []byte) (*TokenInfo, error) {
 	if len(data) < 82 {
 		return nil, fmt.Errorf("invalid mint account data")
 	}
 
 	info := &TokenInfo{
-		Mint: mintAddress,
+		Mint:    mintAddress,
+		Program: program,
 	}
 
-	// Parse mint account data (SPL Token format)
-	// Offset 0-32: mint authority (optional)
-	// Offset 36: decimals
-	// Offset 40-44: is_initialized
-	// Offset 44-76: freeze authority (optional)
+	// Parse mint account data (SPL Token format, shared by Token-2022)
+	// Offset 0-4: mint authority flag, 4-36: mint authority (optional)
+	// Offset 36-44: supply
+	// Offset 44: decimals
+	// Offset 45: is_initialized
+	// Offset 46-50: freeze authority flag, 50-82: freeze authority (optional)
 
+	info.Supply = binary.LittleEndian.Uint64(data[36:44])
 	info.Decimals = data[44]
 
 	// Check if mint authority exists
@@ -76,18 +130,36 @@ func GetTokenInfo(ctx context.Context, client *rpc.Client, mintAddress string) (
 	if freezeAuthFlag == 1 {
 		info.HasFreezeAuthority = true
 		var freezeAuth solana.PublicKey
-		copy(freezeAuth[:], data[46:78])
+		copy(freezeAuth[:], data[50:82])
 		info.FreezeAuthority = &freezeAuth
 	}
 
-	// TODO: Fetch name and symbol from Metaplex metadata
-	// This requires querying the metadata account
-	info.Name = "Unknown"
-	info.Symbol = "UNKNOWN"
+	if info.IsToken2022() {
+		extensions, err := ParseMintExtensions(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse mint extensions: %w", err)
+		}
+		info.Extensions = *extensions
+	}
 
 	return info, nil
 }
 
+// ToUIAmount converts a raw base-unit amount to a human-readable token amount
+func ToUIAmount(raw uint64, decimals uint8) float64 {
+	return float64(raw) / math.Pow10(int(decimals))
+}
+
+// ToRawAmount converts a human-readable token amount to base units
+func ToRawAmount(amount float64, decimals uint8) uint64 {
+	return uint64(amount * math.Pow10(int(decimals)))
+}
+
+// FormatUIAmount formats a raw amount with exactly the token's decimals
+func FormatUIAmount(raw uint64, decimals uint8) string {
+	return fmt.Sprintf("%.*f", decimals, ToUIAmount(raw, decimals))
+}
+
 // GetTokenSupply fetches the total supply of a token
 func GetTokenSupply(ctx context.Context, client *rpc.Client, mintAddress string) (uint64, error) {
 	mint, err := solana.PublicKeyFromBase58(mintAddress)
@@ -106,7 +178,8 @@ func GetTokenSupply(ctx context.Context, client *rpc.Client, mintAddress string)
 	return amount, nil
 }
 
-// GetTokenHolders fetches all token account holders
+// GetTokenHolders fetches all token account holders across the SPL Token and
+// Token-2022 programs
 // Note: This is expensive on mainnet and may hit rate limits
 func GetTokenHolders(ctx context.Context, client *rpc.Client, mintAddress string) ([]TokenAccountInfo, error) {
 	mint, err := solana.PublicKeyFromBase58(mintAddress)
@@ -116,28 +189,36 @@ func GetTokenHolders(ctx context.Context, client *rpc.Client, mintAddress string
 
 	// Get program accounts for the token program filtered by mint
 	// This is expensive and may not work well with free RPC
-	filters := []rpc.RPCFilter{
-		{
-			Memcmp: &rpc.RPCFilterMemcmp{
-				Offset: 0,
-				Bytes:  solana.Base58(mint.Bytes()),
-			},
-		},
-		{
-			DataSize: 165, // SPL token account size
+	mintFilter := rpc.RPCFilter{
+		Memcmp: &rpc.RPCFilterMemcmp{
+			Offset: 0,
+			Bytes:  solana.Base58(mint.Bytes()),
 		},
 	}
+	queries := []struct {
+		program solana.PublicKey
+		filters []rpc.RPCFilter
+	}{
+		// SPL token accounts are always 165 bytes
+		{solana.TokenProgramID, []rpc.RPCFilter{mintFilter, {DataSize: 165}}},
+		// Token-2022 accounts grow with their extensions
+		{solana.Token2022ProgramID, []rpc.RPCFilter{mintFilter}},
+	}
 
-	accounts, err := client.GetProgramAccountsWithOpts(
-		ctx,
-		solana.TokenProgramID,
-		&rpc.GetProgramAccountsOpts{
-			Filters: filters,
-			Encoding: solana.EncodingBase64,
-		},
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get token accounts: %w", err)
+	var accounts rpc.GetProgramAccountsResult
+	for _, query := range queries {
+		result, err := client.GetProgramAccountsWithOpts(
+			ctx,
+			query.program,
+			&rpc.GetProgramAccountsOpts{
+				Filters: query.filters,
+				Encoding: solana.EncodingBase64,
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get token accounts: %w", err)
+		}
+		accounts = append(accounts, result...)
 	}
 
 	holders := make([]TokenAccountInfo, 0)
@@ -147,7 +228,7 @@ func GetTokenHolders(ctx context.Context, client *rpc.Client, mintAddress string
 		}
 
 		data := account.Account.Data.GetBinary()
-		if len(data) < 72 {
+		if !isTokenAccount(data) {
 			continue
 		}
 
@@ -179,45 +260,3 @@ func GetTokenHolders(ctx context.Context, client *rpc.Client, mintAddress string
 
 	return holders, nil
 }
-
-// AnalyzeHolderDistribution calculates holder concentration metrics
-func AnalyzeHolderDistribution(holders []TokenAccountInfo) (holderCount int, topHolderPct float64, top10Pct float64) {
-	if len(holders) == 0 {
-		return 0, 0, 0
-	}
-
-	// Calculate total supply
-	var totalSupply uint64
-	for _, holder := range holders {
-		totalSupply += holder.Amount
-	}
-
-	if totalSupply == 0 {
-		return len(holders), 0, 0
-	}
-
-	// Sort by amount (descending) - simple bubble sort for small data
-	sorted := make([]TokenAccountInfo, len(holders))
-	copy(sorted, holders)
-	for i := 0; i < len(sorted)-1; i++ {
-		for j := 0; j < len(sorted)-i-1; j++ {
-			if sorted[j].Amount < sorted[j+1].Amount {
-				sorted[j], sorted[j+1] = sorted[j+1], sorted[j]
-			}
-		}
-	}
-
-	// Top holder percentage
-	if len(sorted) > 0 {
-		topHolderPct = float64(sorted[0].Amount) / float64(totalSupply) * 100
-	}
-
-	// Top 10 holders percentage
-	var top10Supply uint64
-	for i := 0; i < 10 && i < len(sorted); i++ {
-		top10Supply += sorted[i].Amount
-	}
-	top10Pct = float64(top10Supply) / float64(totalSupply) * 100
-
-	return len(holders), topHolderPct, top10Pct
-}
@@ -0,0 +1,163 @@
+package solana
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// Token-2022 extension types (spl-token-2022 ExtensionType)
+const (
+	extTransferFeeConfig   uint16 = 1
+	extDefaultAccountState uint16 = 6
+	extNonTransferable     uint16 = 9
+	extPermanentDelegate   uint16 = 12
+	extTransferHook        uint16 = 14
+	extTokenMetadata       uint16 = 19
+)
+
+const (
+	// Extended mints are padded to the token account size, followed by the
+	// account type byte and the TLV extension entries
+	token2022AccountTypeOffset = 165
+	token2022ExtensionsOffset  = 166
+
+	token2022AccountTypeMint    = 1
+	token2022AccountTypeAccount = 2
+
+	accountStateFrozen = 2
+)
+
+// MintExtensions holds the risk-relevant Token-2022 extensions of a mint.
+// All fields are zero for legacy SPL Token mints.
+type MintExtensions struct {
+	Types             []uint16          // Every extension type present on the mint
+	TransferFeeBps    uint16            // Highest of the current and scheduled transfer fee
+	MaxTransferFee    uint64            // Fee cap in raw token units for that fee
+	TransferHook      *solana.PublicKey // Program invoked on every transfer
+	PermanentDelegate *solana.PublicKey // Can move or burn tokens from any account
+	DefaultFrozen     bool              // New token accounts start frozen
+	NonTransferable   bool              // Tokens can't be moved once received
+	Metadata          *TokenMetadata    // Metadata stored on the mint itself
+}
+
+// ParseMintExtensions decodes the TLV extensions that follow the base mint
+// layout in a Token-2022 mint account
+func ParseMintExtensions(data []byte) (*MintExtensions, error) {
+	ext := &MintExtensions{}
+	if len(data) <= token2022AccountTypeOffset {
+		return ext, nil // No extensions
+	}
+	if data[token2022AccountTypeOffset] != token2022AccountTypeMint {
+		return nil, fmt.Errorf("not a mint account")
+	}
+
+	pos := token2022ExtensionsOffset
+	for pos+4 <= len(data) {
+		extType := binary.LittleEndian.Uint16(data[pos : pos+2])
+		length := int(binary.LittleEndian.Uint16(data[pos+2 : pos+4]))
+		pos += 4
+		if extType == 0 {
+			break // Uninitialized, the rest is padding
+		}
+		if pos+length > len(data) {
+			return nil, fmt.Errorf("extension %d overruns account data", extType)
+		}
+		value := data[pos : pos+length]
+		pos += length
+
+		ext.Types = append(ext.Types, extType)
+		if err := ext.apply(extType, value); err != nil {
+			return nil, fmt.Errorf("invalid extension %d: %w", extType, err)
+		}
+	}
+
+	return ext, nil
+}
+
+func (e *MintExtensions) apply(extType uint16, value []byte) error {
+	switch extType {
+	case extTransferFeeConfig:
+		// config authority (32), withdraw authority (32), withheld amount (8),
+		// older fee and newer fee: epoch (8), maximum fee (8), basis points (2)
+		if len(value) < 108 {
+			return fmt.Errorf("short transfer fee config")
+		}
+		for _, offset := range []int{72, 90} {
+			maxFee := binary.LittleEndian.Uint64(value[offset+8 : offset+16])
+			bps := binary.LittleEndian.Uint16(value[offset+16 : offset+18])
+			if bps >= e.TransferFeeBps {
+				e.TransferFeeBps = bps
+				e.MaxTransferFee = maxFee
+			}
+		}
+
+	case extTransferHook:
+		// authority (32), program id (32)
+		if len(value) < 64 {
+			return fmt.Errorf("short transfer hook")
+		}
+		if program := solana.PublicKeyFromBytes(value[32:64]); !program.IsZero() {
+			e.TransferHook = &program
+		}
+
+	case extPermanentDelegate:
+		if len(value) < 32 {
+			return fmt.Errorf("short permanent delegate")
+		}
+		if delegate := solana.PublicKeyFromBytes(value[0:32]); !delegate.IsZero() {
+			e.PermanentDelegate = &delegate
+		}
+
+	case extDefaultAccountState:
+		if len(value) < 1 {
+			return fmt.Errorf("short default account state")
+		}
+		e.DefaultFrozen = value[0] == accountStateFrozen
+
+	case extNonTransferable:
+		e.NonTransferable = true
+
+	case extTokenMetadata:
+		meta, err := parseTokenMetadataExtension(value)
+		if err != nil {
+			return err
+		}
+		e.Metadata = meta
+	}
+	return nil
+}
+
+// TransferFeePct is the transfer fee as a percentage of the amount moved
+func (e *MintExtensions) TransferFeePct() float64 {
+	return float64(e.TransferFeeBps) / 100
+}
+
+// parseTokenMetadataExtension decodes the token-metadata interface layout:
+// update authority (32, zero = immutable), mint (32), name, symbol, uri
+func parseTokenMetadataExtension(value []byte) (*TokenMetadata, error) {
+	r := &borshReader{data: value}
+
+	meta := &TokenMetadata{}
+	meta.UpdateAuthority = r.publicKey()
+	r.skip(32) // mint
+	meta.Name = r.string()
+	meta.Symbol = r.string()
+	meta.URI = r.string()
+	meta.IsMutable = !meta.UpdateAuthority.IsZero()
+
+	if r.err != nil {
+		return nil, r.err
+	}
+	return meta, nil
+}
+
+// isTokenAccount reports whether data holds a token account (not a mint) of
+// either token program
+func isTokenAccount(data []byte) bool {
+	if len(data) < token2022AccountTypeOffset {
+		return false
+	}
+	return len(data) == token2022AccountTypeOffset || data[token2022AccountTypeOffset] == token2022AccountTypeAccount
+}
@@ -0,0 +1,146 @@
+package solana
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// extendedMint builds a Token-2022 mint account with the given TLV entries
+func extendedMint(entries ...[]byte) []byte {
+	data := make([]byte, token2022ExtensionsOffset)
+	data[token2022AccountTypeOffset] = token2022AccountTypeMint
+	for _, entry := range entries {
+		data = append(data, entry...)
+	}
+	return data
+}
+
+func tlv(extType uint16, value []byte) []byte {
+	entry := make([]byte, 4, 4+len(value))
+	binary.LittleEndian.PutUint16(entry[0:2], extType)
+	binary.LittleEndian.PutUint16(entry[2:4], uint16(len(value)))
+	return append(entry, value...)
+}
+
+func borshString(s string) []byte {
+	out := make([]byte, 4, 4+len(s))
+	binary.LittleEndian.PutUint32(out, uint32(len(s)))
+	return append(out, s...)
+}
+
+func transferFeeConfig(olderBps, newerBps uint16, olderMax, newerMax uint64) []byte {
+	value := make([]byte, 108)
+	binary.LittleEndian.PutUint64(value[80:88], olderMax)
+	binary.LittleEndian.PutUint16(value[88:90], olderBps)
+	binary.LittleEndian.PutUint64(value[98:106], newerMax)
+	binary.LittleEndian.PutUint16(value[106:108], newerBps)
+	return value
+}
+
+func TestParseMintExtensions(t *testing.T) {
+	hook := solana.NewWallet().PublicKey()
+	delegate := solana.NewWallet().PublicKey()
+	authority := solana.NewWallet().PublicKey()
+
+	hookValue := make([]byte, 64)
+	copy(hookValue[32:], hook[:])
+
+	var metaValue []byte
+	metaValue = append(metaValue, authority[:]...)
+	metaValue = append(metaValue, make([]byte, 32)...) // Mint
+	metaValue = append(metaValue, borshString("Test Token")...)
+	metaValue = append(metaValue, borshString("TEST")...)
+	metaValue = append(metaValue, borshString("https://example.com/test.json")...)
+
+	data := extendedMint(
+		tlv(extTransferFeeConfig, transferFeeConfig(100, 500, 1_000, 5_000)),
+		tlv(extTransferHook, hookValue),
+		tlv(extPermanentDelegate, delegate[:]),
+		tlv(extDefaultAccountState, []byte{accountStateFrozen}),
+		tlv(extNonTransferable, nil),
+		tlv(extTokenMetadata, metaValue),
+		tlv(0, nil), // Padding ends the list
+		[]byte{0xff, 0xff, 0xff, 0xff},
+	)
+
+	ext, err := ParseMintExtensions(data)
+	if err != nil {
+		t.Fatalf("ParseMintExtensions: %v", err)
+	}
+	if len(ext.Types) != 6 {
+		t.Errorf("Types = %v, want 6 extensions", ext.Types)
+	}
+	if ext.TransferFeeBps != 500 || ext.MaxTransferFee != 5_000 || ext.TransferFeePct() != 5 {
+		t.Errorf("transfer fee = %d bps (max %d), want the higher scheduled 500 bps (max 5000)", ext.TransferFeeBps, ext.MaxTransferFee)
+	}
+	if ext.TransferHook == nil || !ext.TransferHook.Equals(hook) {
+		t.Errorf("TransferHook = %v, want %s", ext.TransferHook, hook)
+	}
+	if ext.PermanentDelegate == nil || !ext.PermanentDelegate.Equals(delegate) {
+		t.Errorf("PermanentDelegate = %v, want %s", ext.PermanentDelegate, delegate)
+	}
+	if !ext.DefaultFrozen || !ext.NonTransferable {
+		t.Errorf("DefaultFrozen = %v, NonTransferable = %v, want both", ext.DefaultFrozen, ext.NonTransferable)
+	}
+	if meta := ext.Metadata; meta == nil || meta.Name != "Test Token" || meta.Symbol != "TEST" || !meta.IsMutable {
+		t.Errorf("Metadata = %+v, want mutable Test Token (TEST)", ext.Metadata)
+	}
+}
+
+func TestParseMintExtensionsEdgeCases(t *testing.T) {
+	// A legacy-sized mint has no extensions
+	ext, err := ParseMintExtensions(make([]byte, 82))
+	if err != nil || len(ext.Types) != 0 {
+		t.Errorf("ParseMintExtensions(legacy mint) = %+v, %v", ext, err)
+	}
+
+	// Zeroed hook program and delegate mean the extension is present but unset
+	ext, err = ParseMintExtensions(extendedMint(
+		tlv(extTransferHook, make([]byte, 64)),
+		tlv(extPermanentDelegate, make([]byte, 32)),
+	))
+	if err != nil {
+		t.Fatalf("ParseMintExtensions: %v", err)
+	}
+	if ext.TransferHook != nil || ext.PermanentDelegate != nil {
+		t.Errorf("unset hook or delegate parsed as set: %+v", ext)
+	}
+
+	account := extendedMint()
+	account[token2022AccountTypeOffset] = token2022AccountTypeAccount
+	if _, err := ParseMintExtensions(account); err == nil {
+		t.Error("ParseMintExtensions accepted a token account")
+	}
+
+	overrun := extendedMint(tlv(extPermanentDelegate, make([]byte, 32)))
+	if _, err := ParseMintExtensions(overrun[:len(overrun)-1]); err == nil {
+		t.Error("ParseMintExtensions accepted an extension overrunning the account")
+	}
+
+	if _, err := ParseMintExtensions(extendedMint(tlv(extTransferFeeConfig, make([]byte, 100)))); err == nil {
+		t.Error("ParseMintExtensions accepted a short transfer fee config")
+	}
+}
+
+func TestIsTokenAccount(t *testing.T) {
+	account := extendedMint()
+	account[token2022AccountTypeOffset] = token2022AccountTypeAccount
+
+	tests := []struct {
+		name string
+		data []byte
+		want bool
+	}{
+		{"legacy token account", make([]byte, token2022AccountTypeOffset), true},
+		{"extended token account", account, true},
+		{"legacy mint", make([]byte, 82), false},
+		{"extended mint", extendedMint(), false},
+	}
+	for _, tt := range tests {
+		if got := isTokenAccount(tt.data); got != tt.want {
+			t.Errorf("isTokenAccount(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package solana
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func TestParseMintAccount(t *testing.T) {
+	mintAuthority := solana.NewWallet().PublicKey()
+	freezeAuthority := solana.NewWallet().PublicKey()
+
+	data := make([]byte, 82)
+	data[0] = 1
+	copy(data[4:36], mintAuthority[:])
+	binary.LittleEndian.PutUint64(data[36:44], 1_000_000_000_000_000)
+	data[44] = 6
+	data[45] = 1
+	data[46] = 1
+	copy(data[50:82], freezeAuthority[:])
+
+	info, err := parseMintAccount("mint", solana.TokenProgramID, data)
+	if err != nil {
+		t.Fatalf("parseMintAccount: %v", err)
+	}
+	if info.Supply != 1_000_000_000_000_000 {
+		t.Errorf("Supply = %d, want 1e15", info.Supply)
+	}
+	if info.Decimals != 6 {
+		t.Errorf("Decimals = %d, want 6", info.Decimals)
+	}
+	if !info.HasMintAuthority || !info.MintAuthority.Equals(mintAuthority) {
+		t.Errorf("MintAuthority = %v, want %s", info.MintAuthority, mintAuthority)
+	}
+	if !info.HasFreezeAuthority || !info.FreezeAuthority.Equals(freezeAuthority) {
+		t.Errorf("FreezeAuthority = %v, want %s", info.FreezeAuthority, freezeAuthority)
+	}
+
+	// Revoked authorities
+	data[0], data[46] = 0, 0
+	info, err = parseMintAccount("mint", solana.TokenProgramID, data)
+	if err != nil {
+		t.Fatalf("parseMintAccount: %v", err)
+	}
+	if info.HasMintAuthority || info.MintAuthority != nil || info.HasFreezeAuthority || info.FreezeAuthority != nil {
+		t.Errorf("revoked authorities parsed as set: %+v", info)
+	}
+
+	if _, err := parseMintAccount("mint", solana.TokenProgramID, data[:81]); err == nil {
+		t.Error("parseMintAccount accepted a short account")
+	}
+}
@@ -270,20 +270,44 @@ func ListStrategies() []string {
 	return strategies
 }
 
-// ApplyStrategy applies a strategy preset to an existing config
-// Base config values are overridden by strategy values
+// ApplyStrategy applies a strategy preset to an existing config. The preset
+// is merged onto the base config field by field: it only overrides the entry
+// size, filter thresholds and exits it tunes (see apply), and every other
+// setting keeps the base value. New settings are therefore the user's unless
+// a preset explicitly takes them over.
 func ApplyStrategy(baseConfig *models.Config, strategyName string) (*models.Config, error) {
 	strategy, err := GetStrategy(strategyName)
 	if err != nil {
 		return nil, err
 	}
 
-	// Start with strategy config
-	config := strategy.Config
+	config := *baseConfig
+	strategy.apply(&config)
+	return &config, nil
+}
 
-	// Preserve some base config values that shouldn't be overridden
-	config.Solana = baseConfig.Solana     // Keep RPC/wallet settings
-	config.Listener = baseConfig.Listener // Keep listener settings
+// apply overrides the settings a preset owns
+func (s Strategy) apply(config *models.Config) {
+	preset := s.Config
 
-	return &config, nil
+	config.Engine.Mode = preset.Engine.Mode
+	config.Engine.MaxPositions = preset.Engine.MaxPositions
+
+	config.Trading.BaseMint = preset.Trading.BaseMint
+	config.Trading.QuoteMint = preset.Trading.QuoteMint
+	config.Trading.MaxSpendPerTrade = preset.Trading.MaxSpendPerTrade
+	config.Trading.MaxOpenPositions = preset.Trading.MaxOpenPositions
+	config.Trading.SlippageBps = preset.Trading.SlippageBps
+	config.Trading.PriorityFeeMicroLamports = preset.Trading.PriorityFeeMicroLamports
+
+	config.Rules.MinLiquidityUSD = preset.Rules.MinLiquidityUSD
+	config.Rules.MaxMintAgeSec = preset.Rules.MaxMintAgeSec
+	config.Rules.MinHolders = preset.Rules.MinHolders
+	config.Rules.DevWalletMaxPct = preset.Rules.DevWalletMaxPct
+	config.Rules.BlockFreezeAuthority = preset.Rules.BlockFreezeAuthority
+	config.Rules.AllowMintAuthority = preset.Rules.AllowMintAuthority
+
+	config.Risk.StopLossPct = preset.Risk.StopLossPct
+	config.Risk.TakeProfitPct = preset.Risk.TakeProfitPct
+	config.Risk.MaxTradeDurationSec = preset.Risk.MaxTradeDurationSec
 }
@@ -0,0 +1,88 @@
+package strategies
+
+import (
+	"testing"
+
+	"github.com/speier/tokenscout/internal/models"
+)
+
+func TestApplyStrategyKeepsUserSettings(t *testing.T) {
+	base := &models.Config{
+		Engine: models.EngineConfig{Mode: models.ModeLive, MaxPositions: 9},
+		Solana: models.SolanaConfig{RPCURL: "https://rpc.example"},
+		Trading: models.TradingConfig{
+			MaxSpendPerTrade:  1,
+			SlippageBps:       50,
+			SizingMode:        models.SizingPriceImpact,
+			WalletPct:         5,
+			MaxPriceImpactPct: 3,
+			MinSpendPerTrade:  0.02,
+		},
+		Rules: models.RulesConfig{MinHolders: 99, MinLPBurnedPct: 90},
+		Risk: models.RiskConfig{
+			StopLossPct:         50,
+			TrailingStopPct:     12,
+			BreakevenTriggerPct: 20,
+			MaxDailyLossUSD:     100,
+			TakeProfitLadder: []models.TakeProfitStep{
+				{GainPct: 50, SellPct: 25},
+				{GainPct: 100, SellPct: 25},
+			},
+		},
+	}
+
+	config, err := ApplyStrategy(base, "snipe_flip")
+	if err != nil {
+		t.Fatalf("ApplyStrategy: %v", err)
+	}
+	preset := SnipeFlipStrategy().Config
+
+	// Fields the preset owns
+	if config.Engine != preset.Engine {
+		t.Errorf("Engine = %+v, want %+v", config.Engine, preset.Engine)
+	}
+	if config.Trading.MaxSpendPerTrade != preset.Trading.MaxSpendPerTrade {
+		t.Errorf("MaxSpendPerTrade = %v, want %v", config.Trading.MaxSpendPerTrade, preset.Trading.MaxSpendPerTrade)
+	}
+	if config.Rules.MinHolders != preset.Rules.MinHolders {
+		t.Errorf("MinHolders = %v, want %v", config.Rules.MinHolders, preset.Rules.MinHolders)
+	}
+	if config.Risk.StopLossPct != preset.Risk.StopLossPct {
+		t.Errorf("StopLossPct = %v, want %v", config.Risk.StopLossPct, preset.Risk.StopLossPct)
+	}
+
+	// Everything else is the user's
+	if config.Solana != base.Solana {
+		t.Errorf("Solana = %+v, want %+v", config.Solana, base.Solana)
+	}
+	if config.Risk.TrailingStopPct != 12 {
+		t.Errorf("TrailingStopPct = %v, want 12", config.Risk.TrailingStopPct)
+	}
+	if config.Risk.BreakevenTriggerPct != 20 {
+		t.Errorf("BreakevenTriggerPct = %v, want 20", config.Risk.BreakevenTriggerPct)
+	}
+	if config.Risk.MaxDailyLossUSD != 100 {
+		t.Errorf("MaxDailyLossUSD = %v, want 100", config.Risk.MaxDailyLossUSD)
+	}
+	if len(config.Risk.TakeProfitLadder) != 2 || config.Risk.TakeProfitLadder[1] != base.Risk.TakeProfitLadder[1] {
+		t.Errorf("TakeProfitLadder = %+v, want %+v", config.Risk.TakeProfitLadder, base.Risk.TakeProfitLadder)
+	}
+	if config.Trading.SizingMode != models.SizingPriceImpact || config.Trading.WalletPct != 5 ||
+		config.Trading.MaxPriceImpactPct != 3 || config.Trading.MinSpendPerTrade != 0.02 {
+		t.Errorf("sizing settings = %+v, want the base config's", config.Trading)
+	}
+	if config.Rules.MinLPBurnedPct != 90 {
+		t.Errorf("MinLPBurnedPct = %v, want 90", config.Rules.MinLPBurnedPct)
+	}
+
+	// The base config itself is left alone
+	if base.Rules.MinHolders != 99 || base.Engine.Mode != models.ModeLive {
+		t.Errorf("base config was modified: %+v", base)
+	}
+}
+
+func TestApplyStrategyUnknown(t *testing.T) {
+	if _, err := ApplyStrategy(&models.Config{}, "nope"); err == nil {
+		t.Fatal("expected an error for an unknown strategy")
+	}
+}